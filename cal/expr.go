@@ -0,0 +1,459 @@
+package cal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+var errUnterminated = errors.New("unterminated string")
+
+// ExprError is a syntax or evaluation error of an expression.
+type ExprError struct {
+	Pos int // 1-based byte offset in the source
+	Msg string
+}
+
+func (e *ExprError) Error() string {
+	return fmt.Sprintf("expr: position %d: %s", e.Pos, e.Msg)
+}
+
+func exprErrorf(offset int, format string, args ...any) error {
+	return &ExprError{Pos: offset + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Expr is a compiled expression. It is immutable and safe for
+// concurrent use, compile a rule once and evaluate it for every request.
+type Expr struct {
+	src  string
+	root node
+	vars []string
+}
+
+// Compile parses an expression such as
+//
+//	max(a, b) * 1.2 if c > 3 else 0
+//	status == "vip" && (amount >= 100 || coupon != nil)
+//	len(name) > 0 ? upper(name) : "anonymous"
+//
+// The language has numbers, 'single' or "double" quoted strings, true,
+// false and nil, the operators + - * / % == != < <= > >= && || ! (with
+// and, or, not as synonyms), the ternaries c ? a : b and a if c else b,
+// which gives nil without else, and calls to the functions registered with
+// RegisterFunc. Identifiers are variables, a dotted name such as user.age
+// looks up nested maps and struct fields. Numbers are float64, + also
+// concatenates strings and && || only accept booleans.
+//
+// Unknown functions and syntax errors are reported as an *ExprError with
+// their position.
+func Compile(src string) (*Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, funcs: funcSnapshot(), vars: make(map[string]bool)}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, p.unexpected("expected an operator")
+	}
+
+	vars := make([]string, 0, len(p.vars))
+	for v := range p.vars {
+		vars = append(vars, v)
+	}
+	sort.Strings(vars)
+	return &Expr{src: src, root: root, vars: vars}, nil
+}
+
+// MustCompile is like Compile but panics on error, for expressions
+// known at init time.
+func MustCompile(src string) *Expr {
+	e, err := Compile(src)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Evaluate compiles and evaluates src once.
+func Evaluate(src string, vars map[string]any) (any, error) {
+	e, err := Compile(src)
+	if err != nil {
+		return nil, err
+	}
+	return e.Eval(vars)
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Vars returns the sorted names of the variables the expression reads.
+func (e *Expr) Vars() []string {
+	return append([]string(nil), e.vars...)
+}
+
+// Eval evaluates the expression with the variables vars. The result is
+// float64 for numbers, string, bool or nil, or whatever a function returned.
+// A missing variable, a type mismatch or a failing function is reported as
+// an *ExprError with the position of the offending operand.
+func (e *Expr) Eval(vars map[string]any) (any, error) {
+	return e.root.eval(vars)
+}
+
+// EvalFloat evaluates the expression and converts the result to float64.
+func (e *Expr) EvalFloat(vars map[string]any) (float64, error) {
+	v, err := e.Eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := toFloat(v)
+	if !ok {
+		return 0, exprErrorf(0, "result is %s, not a number", typeName(v))
+	}
+	return f, nil
+}
+
+// EvalBool evaluates the expression and requires a bool result.
+func (e *Expr) EvalBool(vars map[string]any) (bool, error) {
+	v, err := e.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, exprErrorf(0, "result is %s, not a bool", typeName(v))
+	}
+	return b, nil
+}
+
+type node interface {
+	eval(vars map[string]any) (any, error)
+	pos() int
+}
+
+type literal struct {
+	at  int
+	val any
+}
+
+func (n *literal) pos() int { return n.at }
+
+func (n *literal) eval(map[string]any) (any, error) {
+	return n.val, nil
+}
+
+type ident struct {
+	at   int
+	name string
+	path []string
+}
+
+func (n *ident) pos() int { return n.at }
+
+func (n *ident) eval(vars map[string]any) (any, error) {
+	v, ok := vars[n.path[0]]
+	if !ok {
+		return nil, exprErrorf(n.at, "undefined variable %q", n.path[0])
+	}
+	for _, key := range n.path[1:] {
+		if v, ok = field(v, key); !ok {
+			return nil, exprErrorf(n.at, "undefined variable %q", n.name)
+		}
+	}
+	return normalize(v), nil
+}
+
+// field returns the value of key in the map or struct v.
+func field(v any, key string) (any, bool) {
+	if m, ok := v.(map[string]any); ok {
+		f, ok := m[key]
+		return f, ok
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		f := rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+		if !f.IsValid() {
+			return nil, false
+		}
+		return f.Interface(), true
+	case reflect.Struct:
+		f := rv.FieldByName(key)
+		if !f.IsValid() || !f.CanInterface() {
+			return nil, false
+		}
+		return f.Interface(), true
+	}
+	return nil, false
+}
+
+// normalize converts every number to float64 so operators deal with one
+// numeric type.
+func normalize(v any) any {
+	if f, ok := toFloat(v); ok {
+		return f
+	}
+	return v
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "nil"
+	case float64:
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+type unaryNode struct {
+	at int
+	op string
+	x  node
+}
+
+func (n *unaryNode) pos() int { return n.at }
+
+func (n *unaryNode) eval(vars map[string]any) (any, error) {
+	v, err := n.x.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "-" {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, exprErrorf(n.at, "cannot negate %s", typeName(v))
+		}
+		return -f, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, exprErrorf(n.at, "cannot apply ! to %s", typeName(v))
+	}
+	return !b, nil
+}
+
+type logicalNode struct {
+	at   int
+	and  bool
+	x, y node
+}
+
+func (n *logicalNode) pos() int { return n.at }
+
+func (n *logicalNode) eval(vars map[string]any) (any, error) {
+	x, err := evalBool(n.x, vars)
+	if err != nil {
+		return nil, err
+	}
+	// short-circuit like Go
+	if x != n.and {
+		return x, nil
+	}
+	return evalBool(n.y, vars)
+}
+
+func evalBool(n node, vars map[string]any) (bool, error) {
+	v, err := n.eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, exprErrorf(n.pos(), "expected a bool, got %s", typeName(v))
+	}
+	return b, nil
+}
+
+type condNode struct {
+	at              int
+	cond, then, els node
+}
+
+func (n *condNode) pos() int { return n.at }
+
+func (n *condNode) eval(vars map[string]any) (any, error) {
+	c, err := evalBool(n.cond, vars)
+	if err != nil {
+		return nil, err
+	}
+	return If(c, n.then, n.els).eval(vars)
+}
+
+type binaryNode struct {
+	at   int
+	op   string
+	x, y node
+}
+
+func (n *binaryNode) pos() int { return n.at }
+
+func (n *binaryNode) eval(vars map[string]any) (any, error) {
+	x, err := n.x.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	y, err := n.y.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(x, y), nil
+	case "!=":
+		return !equal(x, y), nil
+	}
+
+	if xs, ok := x.(string); ok {
+		if ys, ok := y.(string); ok {
+			return n.evalStrings(xs, ys)
+		}
+	}
+	xf, ok1 := x.(float64)
+	yf, ok2 := y.(float64)
+	if !ok1 || !ok2 {
+		return nil, exprErrorf(n.at, "invalid operation: %s %s %s", typeName(x), n.op, typeName(y))
+	}
+	switch n.op {
+	case "+":
+		return xf + yf, nil
+	case "-":
+		return xf - yf, nil
+	case "*":
+		return xf * yf, nil
+	case "/":
+		if yf == 0 {
+			return nil, exprErrorf(n.at, "division by zero")
+		}
+		return xf / yf, nil
+	case "%":
+		if yf == 0 {
+			return nil, exprErrorf(n.at, "division by zero")
+		}
+		return math.Mod(xf, yf), nil
+	case "<":
+		return xf < yf, nil
+	case "<=":
+		return xf <= yf, nil
+	case ">":
+		return xf > yf, nil
+	case ">=":
+		return xf >= yf, nil
+	}
+	return nil, exprErrorf(n.at, "unknown operator %s", n.op)
+}
+
+func (n *binaryNode) evalStrings(x, y string) (any, error) {
+	switch n.op {
+	case "+":
+		return x + y, nil
+	case "<":
+		return x < y, nil
+	case "<=":
+		return x <= y, nil
+	case ">":
+		return x > y, nil
+	case ">=":
+		return x >= y, nil
+	}
+	return nil, exprErrorf(n.at, "invalid operation: string %s string", n.op)
+}
+
+// equal compares operands without panicking on uncomparable values,
+// operands of different types are not equal.
+func equal(x, y any) (eq bool) {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	tx, ty := reflect.TypeOf(x), reflect.TypeOf(y)
+	if tx != ty {
+		return false
+	}
+	if !tx.Comparable() {
+		return reflect.DeepEqual(x, y)
+	}
+	// a comparable struct or array can still hold uncomparable values in
+	// interface fields, == panics on those
+	defer func() {
+		if recover() != nil {
+			eq = reflect.DeepEqual(x, y)
+		}
+	}()
+	return x == y
+}
+
+type callNode struct {
+	at   int
+	name string
+	f    Func
+	args []node
+}
+
+func (n *callNode) pos() int { return n.at }
+
+func (n *callNode) eval(vars map[string]any) (any, error) {
+	args := make([]any, len(n.args))
+	for i, a := range n.args {
+		v, err := a.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := n.f(args...)
+	if err != nil {
+		var ee *ExprError
+		if errors.As(err, &ee) {
+			return nil, err
+		}
+		return nil, exprErrorf(n.at, "%s: %v", n.name, err)
+	}
+	return normalize(v), nil
+}
@@ -0,0 +1,257 @@
+package cal
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Func is a function callable from expressions. Numbers arrive as float64,
+// a returned error is reported at the position of the call.
+type Func func(args ...any) (any, error)
+
+var (
+	funcMu sync.RWMutex
+	funcs  = map[string]Func{
+		"max":       numbersFunc(1, -1, func(xs []float64) any { return MaxOf(xs...) }),
+		"min":       numbersFunc(1, -1, func(xs []float64) any { return MinOf(xs...) }),
+		"sum":       numbersFunc(0, -1, func(xs []float64) any { return Sum(xs...) }),
+		"avg":       numbersFunc(1, -1, func(xs []float64) any { return Mean(xs) }),
+		"abs":       numbersFunc(1, 1, func(xs []float64) any { return math.Abs(xs[0]) }),
+		"floor":     numbersFunc(1, 1, func(xs []float64) any { return math.Floor(xs[0]) }),
+		"ceil":      numbersFunc(1, 1, func(xs []float64) any { return math.Ceil(xs[0]) }),
+		"sqrt":      numbersFunc(1, 1, func(xs []float64) any { return math.Sqrt(xs[0]) }),
+		"pow":       numbersFunc(2, 2, func(xs []float64) any { return math.Pow(xs[0], xs[1]) }),
+		"clamp":     numbersFunc(3, 3, func(xs []float64) any { return Clamp(xs[0], xs[1], xs[2]) }),
+		"round":     roundFunc,
+		"len":       lenFunc,
+		"upper":     stringFunc(strings.ToUpper),
+		"lower":     stringFunc(strings.ToLower),
+		"trim":      stringFunc(strings.TrimSpace),
+		"contains":  stringsFunc(func(s, sub string) any { return strings.Contains(s, sub) }),
+		"hasPrefix": stringsFunc(func(s, p string) any { return strings.HasPrefix(s, p) }),
+		"hasSuffix": stringsFunc(func(s, p string) any { return strings.HasSuffix(s, p) }),
+		"replace":   replaceFunc,
+		"substr":    substrFunc,
+		"str":       strFunc,
+		"num":       numFunc,
+		"if":        ifFunc,
+	}
+)
+
+// RegisterFunc makes f callable as name in expressions compiled afterwards,
+// replacing a builtin of the same name. Builtins are max, min, sum, avg,
+// abs, floor, ceil, sqrt, pow, clamp, round(x[, places]), len, upper,
+// lower, trim, contains, hasPrefix, hasSuffix, replace, substr(s, start[, n]),
+// str, num and if(cond, a, b).
+func RegisterFunc(name string, f Func) {
+	funcMu.Lock()
+	defer funcMu.Unlock()
+	funcs[name] = f
+}
+
+func funcSnapshot() map[string]Func {
+	funcMu.RLock()
+	defer funcMu.RUnlock()
+	m := make(map[string]Func, len(funcs))
+	for k, v := range funcs {
+		m[k] = v
+	}
+	return m
+}
+
+func checkArgs(args []any, min, max int) error {
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		switch {
+		case min == max:
+			return fmt.Errorf("want %d arguments, got %d", min, len(args))
+		case max < 0:
+			return fmt.Errorf("want at least %d arguments, got %d", min, len(args))
+		}
+		return fmt.Errorf("want %d to %d arguments, got %d", min, max, len(args))
+	}
+	return nil
+}
+
+func numbersFunc(min, max int, f func(xs []float64) any) Func {
+	return func(args ...any) (any, error) {
+		if err := checkArgs(args, min, max); err != nil {
+			return nil, err
+		}
+		xs := make([]float64, len(args))
+		for i, a := range args {
+			x, ok := toFloat(a)
+			if !ok {
+				return nil, fmt.Errorf("argument %d is %s, not a number", i+1, typeName(a))
+			}
+			xs[i] = x
+		}
+		return f(xs), nil
+	}
+}
+
+func stringArgs(args []any, min, max int) ([]string, error) {
+	if err := checkArgs(args, min, max); err != nil {
+		return nil, err
+	}
+	ss := make([]string, len(args))
+	for i, a := range args {
+		s, ok := a.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument %d is %s, not a string", i+1, typeName(a))
+		}
+		ss[i] = s
+	}
+	return ss, nil
+}
+
+func stringFunc(f func(string) string) Func {
+	return func(args ...any) (any, error) {
+		ss, err := stringArgs(args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return f(ss[0]), nil
+	}
+}
+
+func stringsFunc(f func(a, b string) any) Func {
+	return func(args ...any) (any, error) {
+		ss, err := stringArgs(args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		return f(ss[0], ss[1]), nil
+	}
+}
+
+// finiteArg returns args[i] as a number that is neither NaN nor ±Inf, for
+// arguments used as counts or indexes.
+func finiteArg(args []any, i int) (float64, error) {
+	x, ok := toFloat(args[i])
+	if !ok {
+		return 0, fmt.Errorf("argument %d is %s, not a number", i+1, typeName(args[i]))
+	}
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return 0, fmt.Errorf("argument %d is %v, not a finite number", i+1, x)
+	}
+	return x, nil
+}
+
+func roundFunc(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 2); err != nil {
+		return nil, err
+	}
+	x, ok := toFloat(args[0])
+	if !ok {
+		return nil, fmt.Errorf("argument 1 is %s, not a number", typeName(args[0]))
+	}
+	if len(args) == 1 {
+		return math.Round(x), nil
+	}
+	places, err := finiteArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	p := math.Pow(10, math.Trunc(places))
+	switch {
+	case math.IsInf(p, 0) || math.IsInf(x*p, 0):
+		// finer than float64 can hold, x is already rounded
+		return x, nil
+	case p == 0:
+		return 0.0, nil
+	}
+	return math.Round(x*p) / p, nil
+}
+
+func lenFunc(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return nil, err
+	}
+	s, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("argument 1 is %s, not a string", typeName(args[0]))
+	}
+	return utf8.RuneCountInString(s), nil
+}
+
+func replaceFunc(args ...any) (any, error) {
+	ss, err := stringArgs(args, 3, 3)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ReplaceAll(ss[0], ss[1], ss[2]), nil
+}
+
+// substrFunc returns n runes of s from rune index start, or the rest of s.
+func substrFunc(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 3); err != nil {
+		return nil, err
+	}
+	s, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("argument 1 is %s, not a string", typeName(args[0]))
+	}
+	runes := []rune(s)
+	start, err := finiteArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	from := int(Clamp(start, 0, float64(len(runes))))
+	to := len(runes)
+	if len(args) == 3 {
+		n, err := finiteArg(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		to = int(Clamp(float64(from)+n, float64(from), float64(len(runes))))
+	}
+	return string(runes[from:to]), nil
+}
+
+func strFunc(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return nil, err
+	}
+	switch v := args[0].(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case nil:
+		return "", nil
+	}
+	return fmt.Sprint(args[0]), nil
+}
+
+func numFunc(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return nil, err
+	}
+	if f, ok := toFloat(args[0]); ok {
+		return f, nil
+	}
+	s, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert %s to a number", typeName(args[0]))
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %q to a number", s)
+	}
+	return f, nil
+}
+
+func ifFunc(args ...any) (any, error) {
+	if err := checkArgs(args, 3, 3); err != nil {
+		return nil, err
+	}
+	b, ok := args[0].(bool)
+	if !ok {
+		return nil, fmt.Errorf("argument 1 is %s, not a bool", typeName(args[0]))
+	}
+	return If(b, args[1], args[2]), nil
+}
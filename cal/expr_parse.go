@@ -0,0 +1,390 @@
+package cal
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string // operator, identifier or keyword as written, or the unquoted string
+	num  float64
+	pos  int
+}
+
+// twoCharOps lists the operators made of two characters.
+var twoCharOps = []string{"==", "!=", "<=", ">=", "&&", "||"}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isDigit(c) || (c == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			start := i
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				i++
+				if i < len(src) && (src[i] == '+' || src[i] == '-') {
+					i++
+				}
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+			}
+			f, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, exprErrorf(start, "invalid number %q", src[start:i])
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[start:i], num: f, pos: start})
+		case c == '"' || c == '\'':
+			s, n, err := lexString(src[i:])
+			if err != nil {
+				return nil, exprErrorf(i, "%s", err.Error())
+			}
+			tokens = append(tokens, token{kind: tokString, text: s, pos: i})
+			i += n
+		case c == '_' || c >= utf8.RuneSelf || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				i += size
+			}
+			if start == i {
+				return nil, exprErrorf(start, "unexpected character %q", src[i:i+1])
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[start:i], pos: start})
+		default:
+			op := ""
+			for _, o := range twoCharOps {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" && strings.IndexByte("+-*/%()<>!?:,", c) >= 0 {
+				op = src[i : i+1]
+			}
+			if op == "" {
+				return nil, exprErrorf(i, "unexpected character %q", src[i:i+1])
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// lexString reads a quoted string at the start of s and returns its value
+// and length. Both quote styles accept the escapes of Go strings.
+func lexString(s string) (string, int, error) {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			body := s[1:i]
+			if quote == '\'' {
+				body = requote(body)
+			}
+			v, err := strconv.Unquote(`"` + body + `"`)
+			if err != nil {
+				return "", 0, err
+			}
+			return v, i + 1, nil
+		}
+	}
+	return "", 0, errUnterminated
+}
+
+// requote turns the body of a single quoted string into the body of a
+// double quoted one, so strconv handles the escapes of both.
+func requote(body string) string {
+	var sb strings.Builder
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case c == '\\' && i+1 < len(body):
+			i++
+			if body[i] != '\'' {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(body[i])
+		case c == '"':
+			sb.WriteString(`\"`)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// maxDepth bounds the nesting of parentheses, calls, conditionals and
+// unary operators, so hostile input fails instead of exhausting the stack.
+const maxDepth = 256
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+	funcs  map[string]Func
+	vars   map[string]bool
+}
+
+// enter counts one level of nesting, the caller must defer p.leave.
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxDepth {
+		return exprErrorf(p.peek().pos, "expression nested deeper than %d levels", maxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the operators or
+// keywords ops.
+func (p *parser) accept(ops ...string) (token, bool) {
+	t := p.peek()
+	if t.kind != tokOp && t.kind != tokIdent {
+		return t, false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return p.next(), true
+		}
+	}
+	return t, false
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		return p.unexpected("expected " + strconv.Quote(op))
+	}
+	return nil
+}
+
+func (p *parser) unexpected(want string) error {
+	t := p.peek()
+	switch t.kind {
+	case tokEOF:
+		return exprErrorf(t.pos, "unexpected end of expression, %s", want)
+	case tokString:
+		return exprErrorf(t.pos, "unexpected string %q, %s", t.text, want)
+	}
+	return exprErrorf(t.pos, "unexpected %q, %s", t.text, want)
+}
+
+// parseExpr parses, from lowest to highest precedence,
+//
+//	cond ? a : b, a if cond else b
+//	|| or
+//	&& and
+//	== !=
+//	< <= > >=
+//	+ -
+//	* / %
+//	unary - ! not
+func (p *parser) parseExpr() (node, error) {
+	defer p.leave()
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	x, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := p.accept("?"); ok {
+		then, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		els, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &condNode{at: t.pos, cond: x, then: then, els: els}, nil
+	}
+	if t, ok := p.accept("if"); ok {
+		cond, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		n := &condNode{at: t.pos, cond: cond, then: x, els: &literal{at: t.pos}}
+		if _, ok := p.accept("else"); ok {
+			if n.els, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+		return n, nil
+	}
+	return x, nil
+}
+
+// binaryLevels lists the binary operators by increasing precedence.
+var binaryLevels = [][]string{
+	{"||", "or"},
+	{"&&", "and"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+	x, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.accept(binaryLevels[level]...)
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		switch t.text {
+		case "||", "or":
+			x = &logicalNode{at: t.pos, and: false, x: x, y: y}
+		case "&&", "and":
+			x = &logicalNode{at: t.pos, and: true, x: x, y: y}
+		default:
+			x = &binaryNode{at: t.pos, op: t.text, x: x, y: y}
+		}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if t, ok := p.accept("-", "!", "not"); ok {
+		defer p.leave()
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		op := t.text
+		if op == "not" {
+			op = "!"
+		}
+		return &unaryNode{at: t.pos, op: op, x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokNumber:
+		p.next()
+		return &literal{at: t.pos, val: t.num}, nil
+	case tokString:
+		p.next()
+		return &literal{at: t.pos, val: t.text}, nil
+	case tokIdent:
+		switch t.text {
+		case "true", "false":
+			p.next()
+			return &literal{at: t.pos, val: t.text == "true"}, nil
+		case "nil", "null":
+			p.next()
+			return &literal{at: t.pos}, nil
+		case "if":
+			// if(cond, a, b) is a function, a bare if is the ternary
+			if p.tokens[p.pos+1].text != "(" {
+				return nil, p.unexpected("expected an operand")
+			}
+		case "and", "or", "not", "else":
+			return nil, p.unexpected("expected an operand")
+		}
+		p.next()
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(t)
+		}
+		p.vars[t.text] = true
+		return &ident{at: t.pos, name: t.text, path: strings.Split(t.text, ".")}, nil
+	case tokOp:
+		if t.text == "(" {
+			p.next()
+			x, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		}
+	}
+	return nil, p.unexpected("expected an operand")
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	f, ok := p.funcs[name.text]
+	if !ok {
+		return nil, exprErrorf(name.pos, "unknown function %q", name.text)
+	}
+	call := &callNode{at: name.pos, name: name.text, f: f}
+	if _, ok := p.accept(")"); ok {
+		return call, nil
+	}
+	for {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		if _, ok := p.accept(")"); ok {
+			return call, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
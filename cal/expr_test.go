@@ -0,0 +1,172 @@
+package cal
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExprEval(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+	}
+	type box struct{ V any }
+	vars := map[string]any{
+		"p": box{[]int{1}}, "q": box{[]int{1}}, "r": box{[]int{2}},
+		"a": 3, "b": int64(5), "c": 4.5, "s": "Hello", "vip": true,
+		"order": map[string]any{"amount": 120, "items": map[string]int{"apple": 2}},
+		"user":  &user{Name: "Tom", Age: 17},
+	}
+	cases := []struct {
+		src  string
+		want any
+	}{
+		{"1 + 2 * 3", 7.0},
+		{"(1 + 2) * 3", 9.0},
+		{"-a + 10 % 4", -1.0},
+		{"max(a, b) * 1.2 if c > 3", 6.0},
+		{"max(a, b) * 1.2 if c > 5", nil},
+		{"a if c > 5 else b if c > 4 else 0", 5.0},
+		{"c > 4 ? 'big' : 'small'", "big"},
+		{"s + ', ' + \"world\"", "Hello, world"},
+		{`'it\'s' + "\"q\""`, `it's"q"`},
+		{"s == 'Hello' && !(a > b)", true},
+		{"vip and not false or a / 0 > 1", true},
+		{"a == '3'", false},
+		{"nil == null", true},
+		{"p == q && p != r", true},
+		{"round(1.5, 400) + round(1.5, -400)", 1.5},
+		{"order.amount >= 100 && order.items.apple == 2", true},
+		{"user.Age < 18 ? user.Name + ' (minor)' : user.Name", "Tom (minor)"},
+		{"len(upper(s)) + round(2.345, 2)", 7.35},
+		{"substr('中文字符', 1, 2)", "文字"},
+		{"if(vip, 'y', 'n')", "y"},
+		{"num('1.5') + num(str(2))", 3.5},
+		{"contains(s, 'ell') && hasPrefix(s, 'He') && replace(s, 'l', 'L') == 'HeLLo'", true},
+		{"1e3 + .5", 1000.5},
+		{"'b' > 'a'", true},
+	}
+	for _, c := range cases {
+		got, err := Evaluate(c.src, vars)
+		if err != nil {
+			t.Errorf("Evaluate(%q) error: %v", c.src, err)
+			continue
+		}
+		if f, ok := got.(float64); ok {
+			if w, ok := c.want.(float64); ok && almostEqual(f, w) {
+				continue
+			}
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Evaluate(%q) = %#v, want %#v", c.src, got, c.want)
+		}
+	}
+}
+
+func TestExprErrors(t *testing.T) {
+	cases := []struct {
+		src string
+		pos int
+	}{
+		{"1 +", 4},
+		{"(1 + 2", 7},
+		{"1 2", 3},
+		{"foo(1)", 1},
+		{"a # b", 3},
+		{"'abc", 1},
+		{"x + 1", 1},
+		{"1 + 'a' * 2", 9},
+		{"1 / (a - 3)", 3},
+		{"a && true", 1},
+		{"max('a')", 1},
+		{"1 ? 2 : 3", 1},
+		{"1 if", 5},
+		{"substr('abc', sqrt(-1))", 1},
+		{"substr('abc', 0, num('NaN'))", 1},
+		{"substr('abc', pow(10, 400))", 1},
+		{"round(1, num('Inf'))", 1},
+	}
+	for _, c := range cases {
+		_, err := Evaluate(c.src, map[string]any{"a": 3})
+		var ee *ExprError
+		if !errors.As(err, &ee) {
+			t.Errorf("Evaluate(%q) error = %v, want *ExprError", c.src, err)
+			continue
+		}
+		if ee.Pos != c.pos {
+			t.Errorf("Evaluate(%q) error at %d, want %d: %v", c.src, ee.Pos, c.pos, err)
+		}
+	}
+}
+
+func TestExprDeepNesting(t *testing.T) {
+	for _, src := range []string{
+		strings.Repeat("(", 1<<20) + "1" + strings.Repeat(")", 1<<20),
+		strings.Repeat("-", 1<<20) + "1",
+		strings.Repeat("abs(", 1000) + "1" + strings.Repeat(")", 1000),
+		strings.Repeat("1 ? 2 : ", 1000) + "3",
+	} {
+		_, err := Compile(src)
+		var ee *ExprError
+		if !errors.As(err, &ee) {
+			t.Errorf("Compile(%.20q...) error = %v, want *ExprError", src, err)
+		}
+	}
+	if _, err := Compile(strings.Repeat("(", 100) + "1" + strings.Repeat(")", 100)); err != nil {
+		t.Errorf("100 parentheses: %v", err)
+	}
+}
+
+func TestExprCompileOnce(t *testing.T) {
+	e := MustCompile("price * qty * (1 - discount) if qty > 0 else 0")
+	if got := e.Vars(); !reflect.DeepEqual(got, []string{"discount", "price", "qty"}) {
+		t.Errorf("Vars() = %v", got)
+	}
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(qty int) {
+			defer wg.Done()
+			got, err := e.EvalFloat(map[string]any{"price": 10, "qty": qty, "discount": 0.5})
+			if err != nil || got != float64(qty*5) {
+				t.Errorf("qty %d: EvalFloat = %v, %v", qty, got, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if _, err := e.EvalBool(map[string]any{"price": 1, "qty": 1, "discount": 0}); err == nil {
+		t.Error("EvalBool should reject a number")
+	}
+}
+
+func TestRegisterFunc(t *testing.T) {
+	RegisterFunc("double", func(args ...any) (any, error) {
+		if len(args) != 1 {
+			return nil, errors.New("want 1 argument")
+		}
+		f, _ := args[0].(float64)
+		return int(f) * 2, nil
+	})
+	got, err := Evaluate("double(21) + 0", nil)
+	if err != nil || got != 42.0 {
+		t.Errorf("double(21) = %v, %v", got, err)
+	}
+	_, err = Evaluate("1 + double()", nil)
+	var ee *ExprError
+	if !errors.As(err, &ee) || ee.Pos != 5 {
+		t.Errorf("double() error = %v", err)
+	}
+}
+
+func BenchmarkExprEval(b *testing.B) {
+	e := MustCompile("max(a, b) * 1.2 if c > 3 else 0")
+	vars := map[string]any{"a": 3, "b": 5, "c": 4}
+	for i := 0; i < b.N; i++ {
+		_, _ = e.Eval(vars)
+	}
+}
@@ -0,0 +1,179 @@
+package cal
+
+import (
+	"errors"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Number is any integer or floating-point type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// ErrOverflow is returned when an integer operation does not fit its type.
+var ErrOverflow = errors.New("integer overflow")
+
+// MaxOf returns the largest of values, the zero value if there are none.
+// Pass a slice with MaxOf(list...).
+func MaxOf[V constraints.Ordered](values ...V) V {
+	var m V
+	for i, v := range values {
+		if i == 0 || v > m {
+			m = v
+		}
+	}
+	return m
+}
+
+// MinOf returns the smallest of values, the zero value if there are none.
+func MinOf[V constraints.Ordered](values ...V) V {
+	var m V
+	for i, v := range values {
+		if i == 0 || v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// Sum returns the sum of values. Integer sums wrap on overflow, use
+// SafeAdd to detect it.
+func Sum[V Number](values ...V) V {
+	var s V
+	for _, v := range values {
+		s += v
+	}
+	return s
+}
+
+// Product returns the product of values, 1 if there are none.
+func Product[V Number](values ...V) V {
+	p := V(1)
+	for _, v := range values {
+		p *= v
+	}
+	return p
+}
+
+// Clamp limits v to the range [lo, hi].
+func Clamp[V constraints.Ordered](v, lo, hi V) V {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// Abs returns the absolute value of v. The most negative integer of a
+// type has no positive counterpart and is returned as is.
+func Abs[V constraints.Signed | constraints.Float](v V) V {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func isSigned[T constraints.Integer]() bool {
+	var zero T
+	return ^zero < zero
+}
+
+// SafeAdd returns a + b, or ErrOverflow if the result does not fit in T.
+func SafeAdd[T constraints.Integer](a, b T) (T, error) {
+	c := a + b
+	if isSigned[T]() {
+		if (c > a) != (b > 0) {
+			return 0, ErrOverflow
+		}
+	} else if c < a {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// SafeSub returns a - b, or ErrOverflow if the result does not fit in T.
+func SafeSub[T constraints.Integer](a, b T) (T, error) {
+	c := a - b
+	if isSigned[T]() {
+		if (c < a) != (b > 0) {
+			return 0, ErrOverflow
+		}
+	} else if a < b {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// SafeMul returns a * b, or ErrOverflow if the result does not fit in T.
+func SafeMul[T constraints.Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	if isSigned[T]() && (a == ^T(0) || b == ^T(0)) {
+		// multiplying by -1 only overflows for the most negative value,
+		// which is its own negation
+		other := a
+		if a == ^T(0) {
+			other = b
+		}
+		if other < 0 && -other == other {
+			return 0, ErrOverflow
+		}
+		return c, nil
+	}
+	if c/b != a {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// GCD returns the greatest common divisor of the absolute values of a, b
+// and rest. GCD(0, 0) is 0.
+func GCD[T constraints.Integer](a, b T, rest ...T) T {
+	g := gcd(a, b)
+	for _, v := range rest {
+		g = gcd(g, v)
+	}
+	return g
+}
+
+func gcd[T constraints.Integer](a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// LCM returns the least common multiple of the absolute values of a, b and
+// rest, or ErrOverflow if it does not fit in T. LCM is 0 if any input is 0.
+func LCM[T constraints.Integer](a, b T, rest ...T) (T, error) {
+	l, err := lcm(a, b)
+	for i := 0; err == nil && i < len(rest); i++ {
+		l, err = lcm(l, rest[i])
+	}
+	return l, err
+}
+
+func lcm[T constraints.Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	l, err := SafeMul(a/gcd(a, b), b)
+	if err != nil {
+		return 0, err
+	}
+	if l < 0 {
+		if -l < 0 {
+			return 0, ErrOverflow
+		}
+		l = -l
+	}
+	return l, nil
+}
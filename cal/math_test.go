@@ -0,0 +1,94 @@
+package cal
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMaxMinOf(t *testing.T) {
+	if MaxOf(3, 9, -1, 4) != 9 {
+		t.Errorf("MaxOf = %d", MaxOf(3, 9, -1, 4))
+	}
+	if MinOf([]float64{3, 9, -1.5, 4}...) != -1.5 {
+		t.Errorf("MinOf = %v", MinOf([]float64{3, 9, -1.5, 4}...))
+	}
+	if MaxOf[int]() != 0 || MinOf("b", "a", "c") != "a" {
+		t.Error("MaxOf/MinOf edge cases")
+	}
+}
+
+func TestSumProductClampAbs(t *testing.T) {
+	if Sum(1, 2, 3, 4) != 10 || Sum[int]() != 0 {
+		t.Error("Sum")
+	}
+	if Product(1.5, 2, 4) != 12 || Product[int]() != 1 {
+		t.Error("Product")
+	}
+	if Clamp(15, 0, 10) != 10 || Clamp(-1, 0, 10) != 0 || Clamp(5, 0, 10) != 5 {
+		t.Error("Clamp")
+	}
+	if Abs(-3) != 3 || Abs(2.5) != 2.5 || Abs(int8(math.MinInt8)) != math.MinInt8 {
+		t.Error("Abs")
+	}
+}
+
+func TestSafeArithmetic(t *testing.T) {
+	cases := []struct {
+		name string
+		f    func() (int8, error)
+		want int8
+		err  bool
+	}{
+		{"add", func() (int8, error) { return SafeAdd[int8](100, 27) }, 127, false},
+		{"add overflow", func() (int8, error) { return SafeAdd[int8](100, 28) }, 0, true},
+		{"add underflow", func() (int8, error) { return SafeAdd[int8](-100, -29) }, 0, true},
+		{"sub", func() (int8, error) { return SafeSub[int8](-100, 28) }, -128, false},
+		{"sub overflow", func() (int8, error) { return SafeSub[int8](0, -128) }, 0, true},
+		{"mul", func() (int8, error) { return SafeMul[int8](-16, 8) }, -128, false},
+		{"mul overflow", func() (int8, error) { return SafeMul[int8](16, 8) }, 0, true},
+		{"mul min by -1", func() (int8, error) { return SafeMul[int8](-128, -1) }, 0, true},
+		{"mul -1 by min", func() (int8, error) { return SafeMul[int8](-1, -128) }, 0, true},
+		{"mul by -1", func() (int8, error) { return SafeMul[int8](127, -1) }, -127, false},
+	}
+	for _, c := range cases {
+		got, err := c.f()
+		if (err != nil) != c.err || got != c.want {
+			t.Errorf("%s = %d, %v", c.name, got, err)
+		}
+		if c.err && !errors.Is(err, ErrOverflow) {
+			t.Errorf("%s: error %v is not ErrOverflow", c.name, err)
+		}
+	}
+
+	if _, err := SafeAdd[uint8](200, 56); err == nil {
+		t.Error("uint8 add overflow not detected")
+	}
+	if _, err := SafeSub[uint](1, 2); err == nil {
+		t.Error("uint sub underflow not detected")
+	}
+	if _, err := SafeMul[uint64](1<<32, 1<<32); err == nil {
+		t.Error("uint64 mul overflow not detected")
+	}
+	if v, err := SafeMul[uint8](15, 17); err != nil || v != 255 {
+		t.Errorf("SafeMul(15, 17) = %d, %v", v, err)
+	}
+}
+
+func TestGCDLCM(t *testing.T) {
+	if GCD(12, 18) != 6 || GCD(-12, 18) != 6 || GCD(0, 5) != 5 || GCD(0, 0) != 0 {
+		t.Error("GCD")
+	}
+	if GCD(12, 18, 8) != 2 {
+		t.Errorf("GCD(12, 18, 8) = %d", GCD(12, 18, 8))
+	}
+	if l, err := LCM(4, 6, 10); err != nil || l != 60 {
+		t.Errorf("LCM(4, 6, 10) = %d, %v", l, err)
+	}
+	if l, _ := LCM(-4, 6); l != 12 {
+		t.Errorf("LCM(-4, 6) = %d", l)
+	}
+	if _, err := LCM[int8](16, 9); err == nil {
+		t.Error("LCM overflow not detected")
+	}
+}
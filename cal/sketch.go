@@ -0,0 +1,322 @@
+package cal
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// ErrIncompatibleSketch is returned when merging sketches built with
+// different accuracies.
+var ErrIncompatibleSketch = errors.New("cal: sketches have different accuracies")
+
+// binStore counts values per bin index in a dense slice starting at offset.
+type binStore struct {
+	bins   []uint64
+	offset int
+	count  uint64
+}
+
+func (s *binStore) add(index int, n uint64, maxBins int) {
+	if len(s.bins) == 0 {
+		s.bins = []uint64{0}
+		s.offset = index
+	}
+	if index < s.offset {
+		bins := make([]uint64, len(s.bins)+s.offset-index)
+		copy(bins[s.offset-index:], s.bins)
+		s.bins, s.offset = bins, index
+	} else if index >= s.offset+len(s.bins) {
+		s.bins = append(s.bins, make([]uint64, index-s.offset-len(s.bins)+1)...)
+	}
+	s.bins[index-s.offset] += n
+	s.count += n
+
+	if len(s.bins) > maxBins {
+		// fold the lowest bins into the first kept one, only the
+		// accuracy of the smallest magnitudes suffers
+		drop := len(s.bins) - maxBins
+		for _, c := range s.bins[:drop] {
+			s.bins[drop] += c
+		}
+		s.bins = append([]uint64(nil), s.bins[drop:]...)
+		s.offset += drop
+	}
+}
+
+func (s *binStore) merge(o *binStore, maxBins int) {
+	for i, c := range o.bins {
+		if c > 0 {
+			s.add(o.offset+i, c, maxBins)
+		}
+	}
+}
+
+// DDSketch estimates quantiles of a stream with a relative accuracy
+// guarantee: the value returned for any quantile is within alpha of the
+// exact one, relative to its magnitude, so a 1% sketch answers a p99 of
+// 200ms with something between 198ms and 202ms. Values are counted in
+// logarithmic bins, so memory depends on the range of the values, not on
+// their number, and sketches built with the same accuracy can be merged
+// losslessly, such as one per worker combined for a report.
+//
+// See "DDSketch: A Fast and Fully-Mergeable Quantile Sketch with
+// Relative-Error Guarantees", Masson et al., VLDB 2019.
+// It is not safe for concurrent use.
+type DDSketch struct {
+	alpha    float64
+	gamma    float64
+	logGamma float64
+	maxBins  int
+
+	positive, negative binStore
+	zeros              uint64
+	sum, min, max      float64
+}
+
+// DefaultMaxBins bounds the bins of each sign of a DDSketch. With a 1%
+// accuracy 2048 bins cover values from 1 to about 1e17.
+const DefaultMaxBins = 2048
+
+// NewDDSketch returns a sketch with relative accuracy alpha, such as 0.01,
+// clamped to [1e-6, 0.5]. maxBins bounds the memory, DefaultMaxBins is used
+// if it is not positive.
+func NewDDSketch(alpha float64, maxBins int) *DDSketch {
+	alpha = Clamp(alpha, 1e-6, 0.5)
+	if maxBins <= 0 {
+		maxBins = DefaultMaxBins
+	}
+	gamma := (1 + alpha) / (1 - alpha)
+	return &DDSketch{alpha: alpha, gamma: gamma, logGamma: math.Log(gamma), maxBins: maxBins}
+}
+
+// minIndexable is the smallest magnitude given its own bin, smaller
+// magnitudes are counted as zero.
+const minIndexable = 1e-9
+
+func (d *DDSketch) index(x float64) int {
+	return int(math.Ceil(math.Log(x) / d.logGamma))
+}
+
+// value returns the estimate of the bin at index, the point with the same
+// relative distance to both bin bounds.
+func (d *DDSketch) value(index int) float64 {
+	return 2 * math.Pow(d.gamma, float64(index)) / (d.gamma + 1)
+}
+
+// Add adds x to the sketch. NaN and ±Inf are ignored.
+func (d *DDSketch) Add(x float64) {
+	d.AddN(x, 1)
+}
+
+// AddN adds x to the sketch n times. NaN and ±Inf are ignored.
+func (d *DDSketch) AddN(x float64, n uint64) {
+	if math.IsNaN(x) || math.IsInf(x, 0) || n == 0 {
+		return
+	}
+	switch {
+	case x > minIndexable:
+		d.positive.add(d.index(x), n, d.maxBins)
+	case x < -minIndexable:
+		d.negative.add(d.index(-x), n, d.maxBins)
+	default:
+		d.zeros += n
+	}
+	if d.Count() == n {
+		d.min, d.max = x, x
+	} else {
+		d.min = math.Min(d.min, x)
+		d.max = math.Max(d.max, x)
+	}
+	d.sum += x * float64(n)
+}
+
+// Count returns the number of values added.
+func (d *DDSketch) Count() uint64 {
+	return d.positive.count + d.negative.count + d.zeros
+}
+
+// Sum returns the exact sum of the values.
+func (d *DDSketch) Sum() float64 {
+	return d.sum
+}
+
+// Mean returns the exact mean of the values, 0 if there are none.
+func (d *DDSketch) Mean() float64 {
+	if d.Count() == 0 {
+		return 0
+	}
+	return d.sum / float64(d.Count())
+}
+
+// Min returns the exact smallest value, 0 if there are none.
+func (d *DDSketch) Min() float64 {
+	return d.min
+}
+
+// Max returns the exact largest value, 0 if there are none.
+func (d *DDSketch) Max() float64 {
+	return d.max
+}
+
+// Quantile returns an estimate of the q-quantile, q in [0, 1], such as 0.99
+// for the p99. q is clamped to [0, 1], an empty sketch gives 0.
+func (d *DDSketch) Quantile(q float64) float64 {
+	count := d.Count()
+	if count == 0 {
+		return 0
+	}
+	q = Clamp(q, 0, 1)
+	switch q {
+	case 0:
+		return d.min
+	case 1:
+		return d.max
+	}
+	rank := uint64(q * float64(count-1))
+
+	var v float64
+	var seen uint64
+	found := false
+	// negative values from the most negative, that is the largest index
+	for i := len(d.negative.bins) - 1; i >= 0 && !found; i-- {
+		seen += d.negative.bins[i]
+		if seen > rank {
+			v, found = -d.value(d.negative.offset+i), true
+		}
+	}
+	if !found {
+		seen += d.zeros
+		found = seen > rank
+	}
+	for i := 0; i < len(d.positive.bins) && !found; i++ {
+		seen += d.positive.bins[i]
+		if seen > rank {
+			v, found = d.value(d.positive.offset+i), true
+		}
+	}
+	return Clamp(v, d.min, d.max)
+}
+
+// Quantiles returns the estimate of each of qs.
+func (d *DDSketch) Quantiles(qs ...float64) []float64 {
+	result := make([]float64, len(qs))
+	for i, q := range qs {
+		result[i] = d.Quantile(q)
+	}
+	return result
+}
+
+// Merge adds the values of o to d. Both sketches must have been built
+// with the same accuracy, otherwise ErrIncompatibleSketch is returned.
+func (d *DDSketch) Merge(o *DDSketch) error {
+	if d.gamma != o.gamma {
+		return ErrIncompatibleSketch
+	}
+	if o.Count() == 0 {
+		return nil
+	}
+	if d.Count() == 0 {
+		d.min, d.max = o.min, o.max
+	} else {
+		d.min = math.Min(d.min, o.min)
+		d.max = math.Max(d.max, o.max)
+	}
+	d.positive.merge(&o.positive, d.maxBins)
+	d.negative.merge(&o.negative, d.maxBins)
+	d.zeros += o.zeros
+	d.sum += o.sum
+	return nil
+}
+
+// Reset clears the values and keeps the accuracy.
+func (d *DDSketch) Reset() {
+	*d = DDSketch{alpha: d.alpha, gamma: d.gamma, logGamma: d.logGamma, maxBins: d.maxBins}
+}
+
+const sketchVersion = 1
+
+// MarshalBinary encodes the sketch. Only non-empty bins are costly, so a
+// sketch of latencies takes a few hundred bytes.
+func (d *DDSketch) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 64+len(d.positive.bins)+len(d.negative.bins))
+	buf = append(buf, sketchVersion)
+	for _, f := range []float64{d.alpha, d.sum, d.min, d.max} {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(f))
+	}
+	buf = binary.AppendUvarint(buf, uint64(d.maxBins))
+	buf = binary.AppendUvarint(buf, d.zeros)
+	for _, s := range []*binStore{&d.positive, &d.negative} {
+		buf = binary.AppendVarint(buf, int64(s.offset))
+		buf = binary.AppendUvarint(buf, uint64(len(s.bins)))
+		for _, c := range s.bins {
+			buf = binary.AppendUvarint(buf, c)
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces d with data produced by MarshalBinary.
+func (d *DDSketch) UnmarshalBinary(data []byte) error {
+	if len(data) < 33 || data[0] != sketchVersion {
+		return ErrInvalidData
+	}
+	var fs [4]float64
+	for i := range fs {
+		fs[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[1+i*8:]))
+	}
+	data = data[33:]
+
+	r := varintReader{data: data}
+	maxBins := r.uvarint()
+	if !(fs[0] >= 1e-6 && fs[0] <= 0.5) || maxBins == 0 || maxBins > math.MaxInt32 {
+		return ErrInvalidData
+	}
+	s := NewDDSketch(fs[0], int(maxBins))
+	s.sum, s.min, s.max = fs[1], fs[2], fs[3]
+	s.zeros = r.uvarint()
+	for _, store := range []*binStore{&s.positive, &s.negative} {
+		store.offset = int(r.varint())
+		n := r.uvarint()
+		// every bin takes at least one byte, so a count beyond the bytes
+		// left is corrupt and must not size an allocation
+		if n > maxBins || n > uint64(len(r.data)) || r.err != nil {
+			return ErrInvalidData
+		}
+		store.bins = make([]uint64, n)
+		for i := range store.bins {
+			store.bins[i] = r.uvarint()
+			store.count += store.bins[i]
+		}
+	}
+	if r.err != nil || len(r.data) != 0 {
+		return ErrInvalidData
+	}
+	*d = *s
+	return nil
+}
+
+type varintReader struct {
+	data []byte
+	err  error
+}
+
+func (r *varintReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = ErrInvalidData
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *varintReader) varint() int64 {
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err = ErrInvalidData
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
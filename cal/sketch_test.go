@@ -0,0 +1,139 @@
+package cal
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func exactQuantile(sorted []float64, q float64) float64 {
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+func checkAccuracy(t *testing.T, d *DDSketch, sorted []float64, alpha float64) {
+	t.Helper()
+	for _, q := range []float64{0, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999, 1} {
+		want := exactQuantile(sorted, q)
+		got := d.Quantile(q)
+		if math.Abs(got-want) > alpha*math.Abs(want)+1e-9 {
+			t.Errorf("Quantile(%v) = %v, want %v within %v", q, got, want, alpha)
+		}
+	}
+}
+
+func TestDDSketchAccuracy(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	d := NewDDSketch(0.01, 0)
+	list := make([]float64, 20000)
+	for i := range list {
+		// log-normal latencies in milliseconds
+		list[i] = math.Exp(r.NormFloat64() + 3)
+		d.Add(list[i])
+	}
+	sort.Float64s(list)
+	checkAccuracy(t, d, list, 0.01)
+
+	if d.Count() != uint64(len(list)) || d.Min() != list[0] || d.Max() != list[len(list)-1] {
+		t.Errorf("Count/Min/Max = %d/%v/%v", d.Count(), d.Min(), d.Max())
+	}
+	if !almostEqual(d.Mean(), Mean(list)) {
+		t.Errorf("Mean() = %v, want %v", d.Mean(), Mean(list))
+	}
+}
+
+func TestDDSketchNegativeAndZero(t *testing.T) {
+	d := NewDDSketch(0.02, 0)
+	var list []float64
+	for i := -500; i <= 500; i++ {
+		list = append(list, float64(i)/10)
+		d.Add(float64(i) / 10)
+	}
+	d.Add(math.NaN())
+	d.Add(math.Inf(1))
+	d.AddN(math.Inf(-1), 3)
+	sort.Float64s(list)
+	checkAccuracy(t, d, list, 0.02)
+	if d.Quantile(0.5) != 0 {
+		t.Errorf("median = %v, want 0", d.Quantile(0.5))
+	}
+}
+
+func TestDDSketchMerge(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	all := NewDDSketch(0.01, 0)
+	workers := []*DDSketch{NewDDSketch(0.01, 0), NewDDSketch(0.01, 0), NewDDSketch(0.01, 0)}
+	var list []float64
+	for i := 0; i < 9000; i++ {
+		v := r.ExpFloat64() * 100
+		list = append(list, v)
+		all.Add(v)
+		workers[i%3].Add(v)
+	}
+
+	merged := NewDDSketch(0.01, 0)
+	for _, w := range workers {
+		data, err := w.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded DDSketch
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if err := merged.Merge(&decoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, q := range []float64{0.1, 0.5, 0.9, 0.99} {
+		if merged.Quantile(q) != all.Quantile(q) {
+			t.Errorf("merged Quantile(%v) = %v, want %v", q, merged.Quantile(q), all.Quantile(q))
+		}
+	}
+	sort.Float64s(list)
+	checkAccuracy(t, merged, list, 0.01)
+
+	if err := merged.Merge(NewDDSketch(0.05, 0)); err != ErrIncompatibleSketch {
+		t.Errorf("Merge(other accuracy) = %v", err)
+	}
+	var bad DDSketch
+	if err := bad.UnmarshalBinary([]byte{1, 2, 3}); err != ErrInvalidData {
+		t.Errorf("UnmarshalBinary(garbage) = %v", err)
+	}
+	// a huge bin count with no bins behind it
+	crafted := []byte{sketchVersion}
+	crafted = binary.LittleEndian.AppendUint64(crafted, math.Float64bits(0.01))
+	crafted = append(crafted, make([]byte, 24)...)
+	crafted = binary.AppendUvarint(crafted, math.MaxInt32)
+	crafted = append(crafted, 0, 0)
+	crafted = binary.AppendUvarint(crafted, math.MaxInt32)
+	if err := bad.UnmarshalBinary(crafted); err != ErrInvalidData {
+		t.Errorf("UnmarshalBinary(crafted) = %v", err)
+	}
+}
+
+func TestDDSketchMaxBins(t *testing.T) {
+	d := NewDDSketch(0.01, 100)
+	for v := 1.0; v < 1e12; v *= 1.05 {
+		d.Add(v)
+	}
+	if len(d.positive.bins) > 100 {
+		t.Errorf("%d bins, want at most 100", len(d.positive.bins))
+	}
+	// the high quantiles keep their accuracy
+	if got := d.Quantile(1); got < 1e11 {
+		t.Errorf("Quantile(1) = %v", got)
+	}
+	d.Reset()
+	if d.Count() != 0 || d.Quantile(0.5) != 0 {
+		t.Error("Reset left values")
+	}
+}
+
+func BenchmarkDDSketchAdd(b *testing.B) {
+	d := NewDDSketch(0.01, 0)
+	for i := 0; i < b.N; i++ {
+		d.Add(float64(i%10000) + 0.5)
+	}
+}
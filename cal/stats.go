@@ -0,0 +1,201 @@
+package cal
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Mean returns the arithmetic mean of list, 0 for an empty list.
+func Mean[V Number](list []V) float64 {
+	if len(list) == 0 {
+		return 0
+	}
+	var s float64
+	for _, v := range list {
+		s += float64(v)
+	}
+	return s / float64(len(list))
+}
+
+func sortedFloats[V Number](list []V) []float64 {
+	fs := make([]float64, len(list))
+	for i, v := range list {
+		fs[i] = float64(v)
+	}
+	sort.Float64s(fs)
+	return fs
+}
+
+// Median returns the middle value of list, the mean of the two middle
+// values for an even length, 0 for an empty list. list is not modified.
+func Median[V Number](list []V) float64 {
+	return Percentile(list, 50)
+}
+
+// Mode returns the most frequent values of list in ascending order.
+func Mode[V constraints.Ordered](list []V) []V {
+	counts := make(map[V]int, len(list))
+	top := 0
+	for _, v := range list {
+		counts[v]++
+		if counts[v] > top {
+			top = counts[v]
+		}
+	}
+	modes := make([]V, 0)
+	for v, n := range counts {
+		if n == top {
+			modes = append(modes, v)
+		}
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i] < modes[j] })
+	return modes
+}
+
+// variance returns the sum of squared deviations divided by len(list)-ddof,
+// computed in two passes for accuracy.
+func variance[V Number](list []V, ddof int) float64 {
+	if len(list)-ddof <= 0 {
+		return 0
+	}
+	m := Mean(list)
+	var ss float64
+	for _, v := range list {
+		d := float64(v) - m
+		ss += d * d
+	}
+	return ss / float64(len(list)-ddof)
+}
+
+// Variance returns the population variance of list, 0 for an empty list.
+func Variance[V Number](list []V) float64 {
+	return variance(list, 0)
+}
+
+// SampleVariance returns the sample variance of list with Bessel's
+// correction, 0 for fewer than two values.
+func SampleVariance[V Number](list []V) float64 {
+	return variance(list, 1)
+}
+
+// StdDev returns the population standard deviation of list.
+func StdDev[V Number](list []V) float64 {
+	return math.Sqrt(Variance(list))
+}
+
+// SampleStdDev returns the sample standard deviation of list.
+func SampleStdDev[V Number](list []V) float64 {
+	return math.Sqrt(SampleVariance(list))
+}
+
+// Percentile returns the p-th percentile of list, p in [0, 100], linearly
+// interpolated between the closest ranks like Excel PERCENTILE.INC and
+// numpy's default. p is clamped to [0, 100], an empty list gives 0 and a
+// NaN p gives NaN.
+func Percentile[V Number](list []V, p float64) float64 {
+	if len(list) == 0 {
+		return 0
+	}
+	return percentile(sortedFloats(list), p)
+}
+
+// Percentiles returns the percentile of list for each of ps,
+// sorting list only once.
+func Percentiles[V Number](list []V, ps ...float64) []float64 {
+	result := make([]float64, len(ps))
+	if len(list) == 0 {
+		return result
+	}
+	sorted := sortedFloats(list)
+	for i, p := range ps {
+		result[i] = percentile(sorted, p)
+	}
+	return result
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	if math.IsNaN(p) {
+		return math.NaN()
+	}
+	rank := Clamp(p, 0, 100) / 100 * float64(len(sorted)-1)
+	lo := int(rank)
+	if lo == len(sorted)-1 {
+		return sorted[lo]
+	}
+	frac := rank - float64(lo)
+	return sorted[lo] + (sorted[lo+1]-sorted[lo])*frac
+}
+
+// Bucket is a histogram bucket between Low and High with the number of
+// values it holds. Histogram and HistogramBounds tell which end is inclusive.
+type Bucket struct {
+	Low, High float64
+	Count     int
+}
+
+// Histogram splits the range from the minimum to the maximum of list into
+// n buckets of equal width and counts the values in each. Buckets hold
+// values in [Low, High), except the last one which also holds its High.
+// NaN and ±Inf values are skipped, nil is returned if no value is left.
+func Histogram[V Number](list []V, n int) []Bucket {
+	if n <= 0 {
+		return nil
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range list {
+		if f := float64(v); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			lo, hi = math.Min(lo, f), math.Max(hi, f)
+		}
+	}
+	if lo > hi {
+		return nil
+	}
+	width := (hi - lo) / float64(n)
+	buckets := make([]Bucket, n)
+	for i := range buckets {
+		buckets[i].Low = lo + width*float64(i)
+		buckets[i].High = lo + width*float64(i+1)
+	}
+	buckets[n-1].High = hi
+
+	for _, v := range list {
+		if f := float64(v); math.IsNaN(f) || math.IsInf(f, 0) {
+			continue
+		}
+		i := n - 1
+		if width > 0 {
+			i = int((float64(v) - lo) / width)
+			if i >= n {
+				i = n - 1
+			}
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
+
+// HistogramBounds counts the values of list in the buckets delimited by the
+// ascending bounds, like Prometheus: bucket i holds values in
+// (bounds[i-1], bounds[i]] and the last one values above every bound,
+// so there are len(bounds)+1 buckets with infinite outer limits. NaN values
+// are skipped.
+func HistogramBounds[V Number](list []V, bounds []float64) []Bucket {
+	buckets := make([]Bucket, len(bounds)+1)
+	low := math.Inf(-1)
+	for i, b := range bounds {
+		buckets[i].Low, buckets[i].High = low, b
+		low = b
+	}
+	buckets[len(bounds)].Low, buckets[len(bounds)].High = low, math.Inf(1)
+
+	for _, v := range list {
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+		i := sort.SearchFloat64s(bounds, float64(v))
+		buckets[i].Count++
+	}
+	return buckets
+}
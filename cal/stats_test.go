@@ -0,0 +1,99 @@
+package cal
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestMeanMedianMode(t *testing.T) {
+	list := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	if Mean(list) != 5 {
+		t.Errorf("Mean = %v", Mean(list))
+	}
+	if Median(list) != 4.5 || Median([]int{3, 1, 2}) != 2 {
+		t.Errorf("Median = %v", Median(list))
+	}
+	if !reflect.DeepEqual(list, []int{2, 4, 4, 4, 5, 5, 7, 9}) {
+		t.Error("Median modified its input")
+	}
+	if got := Mode(list); !reflect.DeepEqual(got, []int{4}) {
+		t.Errorf("Mode = %v", got)
+	}
+	if got := Mode([]string{"b", "a", "b", "a"}); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Mode = %v", got)
+	}
+	if Mean([]float64{}) != 0 || Median([]int{}) != 0 {
+		t.Error("empty input should give 0")
+	}
+}
+
+func TestVariance(t *testing.T) {
+	list := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	if Variance(list) != 4 || StdDev(list) != 2 {
+		t.Errorf("Variance = %v, StdDev = %v", Variance(list), StdDev(list))
+	}
+	if !almostEqual(SampleVariance(list), 32.0/7) || !almostEqual(SampleStdDev(list), math.Sqrt(32.0/7)) {
+		t.Errorf("SampleVariance = %v", SampleVariance(list))
+	}
+	if SampleVariance([]int{1}) != 0 {
+		t.Error("SampleVariance of one value should be 0")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	list := []float64{15, 20, 35, 40, 50}
+	cases := map[float64]float64{0: 15, 25: 20, 40: 29, 50: 35, 90: 46, 100: 50, -5: 15, 150: 50}
+	for p, want := range cases {
+		if got := Percentile(list, p); !almostEqual(got, want) {
+			t.Errorf("Percentile(%v) = %v, want %v", p, got, want)
+		}
+	}
+	got := Percentiles([]int{1, 2, 3, 4}, 50, 95, 99)
+	if !almostEqual(got[0], 2.5) || !almostEqual(got[1], 3.85) || !almostEqual(got[2], 3.97) {
+		t.Errorf("Percentiles = %v", got)
+	}
+	if got := Percentile(list, math.NaN()); !math.IsNaN(got) {
+		t.Errorf("Percentile(NaN) = %v, want NaN", got)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	buckets := Histogram([]int{0, 1, 2, 5, 9, 10}, 5)
+	counts := make([]int, len(buckets))
+	for i, b := range buckets {
+		counts[i] = b.Count
+	}
+	if !reflect.DeepEqual(counts, []int{2, 1, 1, 0, 2}) {
+		t.Errorf("Histogram counts = %v", counts)
+	}
+	if buckets[0].Low != 0 || buckets[4].High != 10 {
+		t.Errorf("Histogram bounds = %v", buckets)
+	}
+	if b := Histogram([]int{3, 3}, 2); b[1].Count != 2 {
+		t.Errorf("Histogram of equal values = %v", b)
+	}
+	b := Histogram([]float64{math.NaN(), 0, math.Inf(1), 4, math.Inf(-1)}, 2)
+	if len(b) != 2 || b[0].Count != 1 || b[1].Count != 1 || b[1].High != 4 {
+		t.Errorf("Histogram with NaN and Inf = %v", b)
+	}
+	if b := Histogram([]float64{math.NaN()}, 2); b != nil {
+		t.Errorf("Histogram of NaN = %v", b)
+	}
+
+	hb := HistogramBounds([]float64{0.05, 0.1, 0.3, 2, 7}, []float64{0.1, 1, 5})
+	counts = counts[:0]
+	for _, b := range hb {
+		counts = append(counts, b.Count)
+	}
+	if !reflect.DeepEqual(counts, []int{2, 1, 1, 1}) {
+		t.Errorf("HistogramBounds counts = %v", counts)
+	}
+	if !math.IsInf(hb[0].Low, -1) || !math.IsInf(hb[3].High, 1) {
+		t.Errorf("HistogramBounds outer limits = %v", hb)
+	}
+}
@@ -0,0 +1,198 @@
+package cal
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// ErrInvalidData is returned when unmarshaling data that was not produced
+// by the MarshalBinary method of the same type.
+var ErrInvalidData = errors.New("cal: invalid data")
+
+// Welford accumulates the count, mean and variance of a stream of values in
+// constant memory using Welford's algorithm, which stays accurate where the
+// naive sum of squares cancels out. Accumulators of several workers can be
+// combined with Merge. The zero value is ready to use. It is not safe for
+// concurrent use.
+type Welford struct {
+	n        uint64
+	mean, m2 float64
+	min, max float64
+}
+
+// Add adds x to the stream.
+func (w *Welford) Add(x float64) {
+	w.n++
+	if w.n == 1 {
+		w.min, w.max = x, x
+	} else {
+		w.min = math.Min(w.min, x)
+		w.max = math.Max(w.max, x)
+	}
+	d := x - w.mean
+	w.mean += d / float64(w.n)
+	w.m2 += d * (x - w.mean)
+}
+
+// Count returns the number of values added.
+func (w *Welford) Count() uint64 {
+	return w.n
+}
+
+// Mean returns the mean of the values, 0 if there are none.
+func (w *Welford) Mean() float64 {
+	return w.mean
+}
+
+// Min returns the smallest value, 0 if there are none.
+func (w *Welford) Min() float64 {
+	return w.min
+}
+
+// Max returns the largest value, 0 if there are none.
+func (w *Welford) Max() float64 {
+	return w.max
+}
+
+// Variance returns the population variance of the values.
+func (w *Welford) Variance() float64 {
+	if w.n == 0 {
+		return 0
+	}
+	return w.m2 / float64(w.n)
+}
+
+// SampleVariance returns the sample variance of the values,
+// 0 for fewer than two values.
+func (w *Welford) SampleVariance() float64 {
+	if w.n < 2 {
+		return 0
+	}
+	return w.m2 / float64(w.n-1)
+}
+
+// StdDev returns the population standard deviation of the values.
+func (w *Welford) StdDev() float64 {
+	return math.Sqrt(w.Variance())
+}
+
+// Merge adds the values accumulated by o, as if they had been added to w.
+func (w *Welford) Merge(o *Welford) {
+	switch {
+	case o.n == 0:
+		return
+	case w.n == 0:
+		*w = *o
+		return
+	}
+	n := w.n + o.n
+	d := o.mean - w.mean
+	w.mean += d * float64(o.n) / float64(n)
+	w.m2 += o.m2 + d*d*float64(w.n)*float64(o.n)/float64(n)
+	w.min = math.Min(w.min, o.min)
+	w.max = math.Max(w.max, o.max)
+	w.n = n
+}
+
+// Reset clears the accumulated values.
+func (w *Welford) Reset() {
+	*w = Welford{}
+}
+
+const welfordSize = 8 * 5
+
+// MarshalBinary encodes the accumulator in 40 bytes.
+func (w *Welford) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, welfordSize)
+	buf = binary.LittleEndian.AppendUint64(buf, w.n)
+	for _, f := range []float64{w.mean, w.m2, w.min, w.max} {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(f))
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces w with data produced by MarshalBinary.
+func (w *Welford) UnmarshalBinary(data []byte) error {
+	if len(data) != welfordSize {
+		return ErrInvalidData
+	}
+	w.n = binary.LittleEndian.Uint64(data)
+	fs := [4]*float64{&w.mean, &w.m2, &w.min, &w.max}
+	for i, f := range fs {
+		*f = math.Float64frombits(binary.LittleEndian.Uint64(data[8+i*8:]))
+	}
+	return nil
+}
+
+// EWMA is an exponentially weighted moving average: each new value weighs
+// alpha and the previous average 1-alpha, so recent values dominate without
+// keeping a window. The first value initializes the average.
+// It is not safe for concurrent use.
+type EWMA struct {
+	alpha float64
+	value float64
+	init  bool
+}
+
+// NewEWMA returns an average where each new value weighs alpha,
+// which is clamped to (0, 1].
+func NewEWMA(alpha float64) *EWMA {
+	if alpha <= 0 {
+		alpha = math.SmallestNonzeroFloat64
+	}
+	return &EWMA{alpha: math.Min(alpha, 1)}
+}
+
+// NewEWMASpan returns an average over about n values, with alpha = 2/(n+1)
+// like the N-day moving averages of trading charts.
+func NewEWMASpan(n int) *EWMA {
+	return NewEWMA(2 / (float64(n) + 1))
+}
+
+// Add adds x to the average.
+func (e *EWMA) Add(x float64) {
+	if !e.init {
+		e.value, e.init = x, true
+		return
+	}
+	e.value += e.alpha * (x - e.value)
+}
+
+// Value returns the current average, 0 before the first value.
+func (e *EWMA) Value() float64 {
+	return e.value
+}
+
+// Reset forgets the current average and keeps alpha.
+func (e *EWMA) Reset() {
+	e.value, e.init = 0, false
+}
+
+const ewmaSize = 8*2 + 1
+
+// MarshalBinary encodes the average in 17 bytes.
+func (e *EWMA) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, ewmaSize)
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(e.alpha))
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(e.value))
+	if e.init {
+		return append(buf, 1), nil
+	}
+	return append(buf, 0), nil
+}
+
+// UnmarshalBinary replaces e with data produced by MarshalBinary.
+func (e *EWMA) UnmarshalBinary(data []byte) error {
+	if len(data) != ewmaSize || data[16] > 1 {
+		return ErrInvalidData
+	}
+	alpha := math.Float64frombits(binary.LittleEndian.Uint64(data))
+	if !(alpha > 0 && alpha <= 1) {
+		return ErrInvalidData
+	}
+	e.alpha = alpha
+	e.value = math.Float64frombits(binary.LittleEndian.Uint64(data[8:]))
+	e.init = data[16] == 1
+	return nil
+}
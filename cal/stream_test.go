@@ -0,0 +1,103 @@
+package cal
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestWelford(t *testing.T) {
+	list := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	var w Welford
+	for _, v := range list {
+		w.Add(v)
+	}
+	if w.Count() != 8 || w.Mean() != 5 || w.Variance() != 4 || w.StdDev() != 2 {
+		t.Errorf("Welford = n %d, mean %v, var %v", w.Count(), w.Mean(), w.Variance())
+	}
+	if !almostEqual(w.SampleVariance(), SampleVariance(list)) || w.Min() != 2 || w.Max() != 9 {
+		t.Errorf("SampleVariance = %v, min %v, max %v", w.SampleVariance(), w.Min(), w.Max())
+	}
+
+	// large offsets cancel out with the naive sum of squares
+	var big Welford
+	for _, v := range list {
+		big.Add(v + 1e9)
+	}
+	if math.Abs(big.Variance()-4) > 1e-6 {
+		t.Errorf("Variance with offset = %v", big.Variance())
+	}
+}
+
+func TestWelfordMerge(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var all, a, b Welford
+	list := make([]float64, 1000)
+	for i := range list {
+		list[i] = r.NormFloat64()*10 + 50
+		all.Add(list[i])
+		if i%3 == 0 {
+			a.Add(list[i])
+		} else {
+			b.Add(list[i])
+		}
+	}
+	var empty Welford
+	a.Merge(&b)
+	a.Merge(&empty)
+	if a.Count() != all.Count() || !almostEqual(a.Mean(), all.Mean()) || !almostEqual(a.Variance(), all.Variance()) {
+		t.Errorf("merged = %v/%v, want %v/%v", a.Mean(), a.Variance(), all.Mean(), all.Variance())
+	}
+	if a.Min() != MinOf(list...) || a.Max() != MaxOf(list...) {
+		t.Error("merged min/max")
+	}
+	empty.Merge(&all)
+	if empty != all {
+		t.Error("merging into an empty accumulator should copy")
+	}
+
+	data, _ := all.MarshalBinary()
+	var got Welford
+	if err := got.UnmarshalBinary(data); err != nil || got != all {
+		t.Errorf("round trip = %+v, %v", got, err)
+	}
+	if err := got.UnmarshalBinary(data[1:]); err != ErrInvalidData {
+		t.Errorf("UnmarshalBinary(short) = %v", err)
+	}
+}
+
+func TestEWMA(t *testing.T) {
+	e := NewEWMA(0.5)
+	if e.Value() != 0 {
+		t.Error("initial value should be 0")
+	}
+	for _, v := range []float64{10, 20, 20} {
+		e.Add(v)
+	}
+	if e.Value() != 17.5 {
+		t.Errorf("Value() = %v, want 17.5", e.Value())
+	}
+
+	s := NewEWMASpan(9)
+	for i := 0; i < 200; i++ {
+		s.Add(100)
+	}
+	if math.Abs(s.Value()-100) > 1e-9 {
+		t.Errorf("constant stream average = %v", s.Value())
+	}
+
+	data, _ := e.MarshalBinary()
+	var got EWMA
+	if err := got.UnmarshalBinary(data); err != nil || got != *e {
+		t.Errorf("round trip = %+v, %v", got, err)
+	}
+	got.Add(30)
+	if got.Value() != 23.75 {
+		t.Errorf("Value() after round trip = %v", got.Value())
+	}
+	e.Reset()
+	e.Add(3)
+	if e.Value() != 3 {
+		t.Errorf("Value() after Reset = %v", e.Value())
+	}
+}
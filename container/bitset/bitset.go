@@ -0,0 +1,285 @@
+package bitset
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/hy-shine/gotiny/container/slice"
+	"golang.org/x/exp/constraints"
+)
+
+const wordSize = 64
+
+// BitSet is a dense set of non-negative integers, one bit per possible
+// member. It grows as needed and suits IDs that are mostly contiguous; use
+// Bitmap when they are sparse. The zero value is an empty set.
+type BitSet struct {
+	words []uint64
+}
+
+// New returns an empty set with room for n bits before growing.
+func New(n uint) *BitSet {
+	return &BitSet{words: make([]uint64, (n+wordSize-1)/wordSize)}
+}
+
+// From returns a set holding ids.
+func From(ids ...uint) *BitSet {
+	b := &BitSet{}
+	for _, i := range ids {
+		b.Set(i)
+	}
+	return b
+}
+
+// FromRanges returns a set holding the ranges produced by
+// slice.MergeSortedAdjacent, where a range is [start, end] or [single].
+// Negative values are ignored.
+func FromRanges[K constraints.Integer](ranges [][]K) *BitSet {
+	b := &BitSet{}
+	for _, r := range ranges {
+		if len(r) == 0 {
+			continue
+		}
+		start, end := r[0], r[len(r)-1]
+		if end < 0 {
+			continue
+		}
+		if start < 0 {
+			start = 0
+		}
+		b.SetRange(uint(start), uint(end))
+	}
+	return b
+}
+
+func (b *BitSet) grow(i uint) {
+	n := int(i/wordSize) + 1
+	if n <= len(b.words) {
+		return
+	}
+	if n <= cap(b.words) {
+		b.words = b.words[:n]
+		return
+	}
+	words := make([]uint64, n, n+n/2)
+	copy(words, b.words)
+	b.words = words
+}
+
+// Set adds i to the set.
+func (b *BitSet) Set(i uint) {
+	b.grow(i)
+	b.words[i/wordSize] |= 1 << (i % wordSize)
+}
+
+// SetRange adds every integer in [start, end] to the set.
+func (b *BitSet) SetRange(start, end uint) {
+	if start > end {
+		return
+	}
+	b.grow(end)
+	for i := start; i <= end; {
+		w, off := i/wordSize, i%wordSize
+		n := end - i + 1
+		if off == 0 && n >= wordSize {
+			b.words[w] = ^uint64(0)
+			i += wordSize
+			continue
+		}
+		b.words[w] |= 1 << off
+		if i == end {
+			break
+		}
+		i++
+	}
+}
+
+// Clear removes i from the set.
+func (b *BitSet) Clear(i uint) {
+	if w := i / wordSize; w < uint(len(b.words)) {
+		b.words[w] &^= 1 << (i % wordSize)
+	}
+}
+
+// Flip toggles the membership of i.
+func (b *BitSet) Flip(i uint) {
+	b.grow(i)
+	b.words[i/wordSize] ^= 1 << (i % wordSize)
+}
+
+// Test reports whether i is in the set.
+func (b *BitSet) Test(i uint) bool {
+	w := i / wordSize
+	return w < uint(len(b.words)) && b.words[w]&(1<<(i%wordSize)) != 0
+}
+
+// Count returns the number of members.
+func (b *BitSet) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// IsEmpty reports whether the set has no members.
+func (b *BitSet) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Reset removes all members and keeps the memory.
+func (b *BitSet) Reset() {
+	for i := range b.words {
+		b.words[i] = 0
+	}
+}
+
+// NextSet returns the smallest member greater than or equal to i,
+// false if there is none. Iterate over a set with
+//
+//	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {}
+func (b *BitSet) NextSet(i uint) (uint, bool) {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		return 0, false
+	}
+	word := b.words[w] >> (i % wordSize)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word)), true
+	}
+	for w++; w < uint(len(b.words)); w++ {
+		if b.words[w] != 0 {
+			return w*wordSize + uint(bits.TrailingZeros64(b.words[w])), true
+		}
+	}
+	return 0, false
+}
+
+// Range calls f for each member in ascending order until f returns false.
+func (b *BitSet) Range(f func(i uint) bool) {
+	for w, word := range b.words {
+		for word != 0 {
+			t := uint(bits.TrailingZeros64(word))
+			if !f(uint(w)*wordSize + t) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
+
+// ToSlice returns the members in ascending order.
+func (b *BitSet) ToSlice() []uint {
+	list := make([]uint, 0, b.Count())
+	b.Range(func(i uint) bool {
+		list = append(list, i)
+		return true
+	})
+	return list
+}
+
+// Ranges returns the members grouped like slice.MergeSortedAdjacent,
+// so {1, 2, 3, 5} gives [[1, 3], [5]].
+func (b *BitSet) Ranges() [][]uint {
+	return slice.MergeSortedAdjacent(b.ToSlice())
+}
+
+// String formats the set like slice.FormatRanges, such as "1-3,5".
+func (b *BitSet) String() string {
+	var sb strings.Builder
+	for _, r := range b.Ranges() {
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatUint(uint64(r[0]), 10))
+		if len(r) > 1 {
+			sb.WriteByte('-')
+			sb.WriteString(strconv.FormatUint(uint64(r[1]), 10))
+		}
+	}
+	return sb.String()
+}
+
+// Clone returns a copy of the set.
+func (b *BitSet) Clone() *BitSet {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	return &BitSet{words: words}
+}
+
+// Equal reports whether both sets have the same members.
+func (b *BitSet) Equal(o *BitSet) bool {
+	short, long := b.words, o.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	for i := range short {
+		if short[i] != long[i] {
+			return false
+		}
+	}
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// And returns the members found in both b and o.
+func (b *BitSet) And(o *BitSet) *BitSet {
+	n := len(b.words)
+	if len(o.words) < n {
+		n = len(o.words)
+	}
+	words := make([]uint64, n)
+	for i := range words {
+		words[i] = b.words[i] & o.words[i]
+	}
+	return &BitSet{words: words}
+}
+
+// Or returns the members found in b or o.
+func (b *BitSet) Or(o *BitSet) *BitSet {
+	return b.combine(o, func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor returns the members found in exactly one of b and o.
+func (b *BitSet) Xor(o *BitSet) *BitSet {
+	return b.combine(o, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// AndNot returns the members of b that are not in o.
+func (b *BitSet) AndNot(o *BitSet) *BitSet {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	for i := 0; i < len(words) && i < len(o.words); i++ {
+		words[i] &^= o.words[i]
+	}
+	return &BitSet{words: words}
+}
+
+func (b *BitSet) combine(o *BitSet, op func(x, y uint64) uint64) *BitSet {
+	n := len(b.words)
+	if len(o.words) > n {
+		n = len(o.words)
+	}
+	words := make([]uint64, n)
+	for i := range words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(o.words) {
+			y = o.words[i]
+		}
+		words[i] = op(x, y)
+	}
+	return &BitSet{words: words}
+}
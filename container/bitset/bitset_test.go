@@ -0,0 +1,105 @@
+package bitset
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hy-shine/gotiny/container/slice"
+)
+
+func TestBitSetBasic(t *testing.T) {
+	var b BitSet
+	for _, i := range []uint{0, 1, 63, 64, 200} {
+		b.Set(i)
+	}
+	if b.Count() != 5 {
+		t.Errorf("Count() = %d, want 5", b.Count())
+	}
+	if !b.Test(63) || !b.Test(200) || b.Test(2) || b.Test(100000) {
+		t.Error("Test() returned wrong membership")
+	}
+	b.Clear(63)
+	b.Clear(100000)
+	b.Flip(1)
+	b.Flip(2)
+	if got := b.ToSlice(); !reflect.DeepEqual(got, []uint{0, 2, 64, 200}) {
+		t.Errorf("ToSlice() = %v", got)
+	}
+	b.Reset()
+	if !b.IsEmpty() {
+		t.Error("Reset() left members")
+	}
+}
+
+func TestBitSetNextSet(t *testing.T) {
+	b := From(3, 64, 65, 1000)
+	var got []uint
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		got = append(got, i)
+	}
+	if !reflect.DeepEqual(got, []uint{3, 64, 65, 1000}) {
+		t.Errorf("NextSet iteration = %v", got)
+	}
+	if i, ok := b.NextSet(66); !ok || i != 1000 {
+		t.Errorf("NextSet(66) = %d, %v", i, ok)
+	}
+	if _, ok := b.NextSet(1001); ok {
+		t.Error("NextSet past the end should fail")
+	}
+}
+
+func TestBitSetAlgebra(t *testing.T) {
+	a := From(1, 2, 3, 100)
+	b := From(2, 3, 4, 300)
+	cases := []struct {
+		name string
+		got  *BitSet
+		want []uint
+	}{
+		{"And", a.And(b), []uint{2, 3}},
+		{"Or", a.Or(b), []uint{1, 2, 3, 4, 100, 300}},
+		{"Xor", a.Xor(b), []uint{1, 4, 100, 300}},
+		{"AndNot", a.AndNot(b), []uint{1, 100}},
+		{"BAndNot", b.AndNot(a), []uint{4, 300}},
+	}
+	for _, c := range cases {
+		if got := c.got.ToSlice(); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, got, c.want)
+		}
+	}
+	if !a.Equal(From(1, 2, 3, 100).Or(New(1000))) {
+		t.Error("Equal should ignore trailing empty words")
+	}
+	if a.Equal(b) {
+		t.Error("different sets are equal")
+	}
+}
+
+func TestBitSetRanges(t *testing.T) {
+	nums := []int{1, 2, 3, 5, 7, 8, 60, 61, 62, 63, 64, 65, 66, 200}
+	ranges := slice.MergeSortedAdjacent(nums)
+	b := FromRanges(ranges)
+	if b.Count() != len(nums) {
+		t.Fatalf("Count() = %d, want %d", b.Count(), len(nums))
+	}
+	if got := b.String(); got != "1-3,5,7-8,60-66,200" {
+		t.Errorf("String() = %q", got)
+	}
+	want := [][]uint{{1, 3}, {5}, {7, 8}, {60, 66}, {200}}
+	if got := b.Ranges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Ranges() = %v, want %v", got, want)
+	}
+
+	var s BitSet
+	s.SetRange(10, 300)
+	if s.Count() != 291 || !s.Test(10) || !s.Test(300) || s.Test(9) || s.Test(301) {
+		t.Errorf("SetRange(10, 300) = %s", s.String())
+	}
+}
+
+func BenchmarkBitSetSet(b *testing.B) {
+	s := New(1 << 20)
+	for i := 0; i < b.N; i++ {
+		s.Set(uint(i) & (1<<20 - 1))
+	}
+}
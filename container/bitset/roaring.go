@@ -0,0 +1,552 @@
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"sort"
+
+	"github.com/hy-shine/gotiny/container/slice"
+)
+
+const (
+	// arrayMax is the largest container kept as a sorted array,
+	// 4096 uint16 take the same 8KB as a full bitmap container.
+	arrayMax = 4096
+	// arrayMin is the size a bitmap container shrinks back to an array at,
+	// below arrayMax so a container near the limit does not convert on
+	// every add and remove.
+	arrayMin     = arrayMax / 2
+	bitmapWords  = 1 << 16 / wordSize
+	typeArray    = 0
+	typeBitmap   = 1
+	bitmapMagic  = 0x524d4231
+	headerLength = 8
+)
+
+// ErrInvalidBitmap is returned when unmarshaling data that was not
+// produced by Bitmap.MarshalBinary.
+var ErrInvalidBitmap = errors.New("bitset: invalid bitmap data")
+
+// container holds the members sharing the same high 16 bits, as a sorted
+// array while it is sparse and as a 65536 bit bitmap once it is dense.
+type container struct {
+	key    uint16
+	n      int
+	array  []uint16
+	bitmap []uint64
+}
+
+func (c *container) contains(low uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[low/wordSize]&(1<<(low%wordSize)) != 0
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	return i < len(c.array) && c.array[i] == low
+}
+
+func (c *container) add(low uint16) bool {
+	if c.bitmap != nil {
+		w, bit := low/wordSize, uint64(1)<<(low%wordSize)
+		if c.bitmap[w]&bit != 0 {
+			return false
+		}
+		c.bitmap[w] |= bit
+		c.n++
+		return true
+	}
+	if c.contains(low) {
+		return false
+	}
+	c.array = slice.InsertSorted(c.array, low)
+	c.n++
+	if c.n > arrayMax {
+		c.toBitmap()
+	}
+	return true
+}
+
+func (c *container) remove(low uint16) bool {
+	if c.bitmap == nil {
+		var ok bool
+		c.array, ok = slice.RemoveSorted(c.array, low)
+		if ok {
+			c.n--
+		}
+		return ok
+	}
+	w, bit := low/wordSize, uint64(1)<<(low%wordSize)
+	if c.bitmap[w]&bit == 0 {
+		return false
+	}
+	c.bitmap[w] &^= bit
+	c.n--
+	if c.n <= arrayMin {
+		c.toArray()
+	}
+	return true
+}
+
+// addRange adds every value in [lo, hi], filling bitmap words directly.
+func (c *container) addRange(lo, hi int) {
+	if c.bitmap == nil {
+		if c.n+hi-lo+1 <= arrayMax {
+			r := make([]uint16, 0, hi-lo+1)
+			for v := lo; v <= hi; v++ {
+				r = append(r, uint16(v))
+			}
+			c.array = slice.UnionSorted(c.array, r)
+			c.n = len(c.array)
+			return
+		}
+		c.toBitmap()
+	}
+	for w := lo / wordSize; w <= hi/wordSize; w++ {
+		mask := ^uint64(0)
+		if w == lo/wordSize {
+			mask &= ^uint64(0) << (lo % wordSize)
+		}
+		if w == hi/wordSize {
+			mask &= ^uint64(0) >> (wordSize - 1 - hi%wordSize)
+		}
+		c.n += bits.OnesCount64(mask &^ c.bitmap[w])
+		c.bitmap[w] |= mask
+	}
+}
+
+func (c *container) toBitmap() {
+	c.bitmap = make([]uint64, bitmapWords)
+	for _, v := range c.array {
+		c.bitmap[v/wordSize] |= 1 << (v % wordSize)
+	}
+	c.array = nil
+}
+
+func (c *container) toArray() {
+	c.array = make([]uint16, 0, c.n)
+	c.each(func(v uint16) bool {
+		c.array = append(c.array, v)
+		return true
+	})
+	c.bitmap = nil
+}
+
+// fix recounts a bitmap container and shrinks it to an array if it is sparse.
+func (c *container) fix() {
+	if c.bitmap == nil {
+		c.n = len(c.array)
+		return
+	}
+	c.n = 0
+	for _, w := range c.bitmap {
+		c.n += bits.OnesCount64(w)
+	}
+	if c.n <= arrayMax {
+		c.toArray()
+	}
+}
+
+func (c *container) each(f func(v uint16) bool) bool {
+	if c.bitmap == nil {
+		for _, v := range c.array {
+			if !f(v) {
+				return false
+			}
+		}
+		return true
+	}
+	for w, word := range c.bitmap {
+		for word != 0 {
+			if !f(uint16(w*wordSize + bits.TrailingZeros64(word))) {
+				return false
+			}
+			word &= word - 1
+		}
+	}
+	return true
+}
+
+func (c *container) words() []uint64 {
+	if c.bitmap != nil {
+		return c.bitmap
+	}
+	words := make([]uint64, bitmapWords)
+	for _, v := range c.array {
+		words[v/wordSize] |= 1 << (v % wordSize)
+	}
+	return words
+}
+
+func (c *container) clone() *container {
+	return &container{
+		key:    c.key,
+		n:      c.n,
+		array:  append([]uint16(nil), c.array...),
+		bitmap: append([]uint64(nil), c.bitmap...),
+	}
+}
+
+func andContainer(a, b *container) *container {
+	out := &container{key: a.key}
+	switch {
+	case a.bitmap == nil && b.bitmap == nil:
+		out.array = slice.IntersectSorted(a.array, b.array)
+	case a.bitmap == nil || b.bitmap == nil:
+		arr, bm := a, b
+		if arr.bitmap != nil {
+			arr, bm = b, a
+		}
+		for _, v := range arr.array {
+			if bm.contains(v) {
+				out.array = append(out.array, v)
+			}
+		}
+	default:
+		out.bitmap = make([]uint64, bitmapWords)
+		for i := range out.bitmap {
+			out.bitmap[i] = a.bitmap[i] & b.bitmap[i]
+		}
+	}
+	out.fix()
+	return out
+}
+
+func orContainer(a, b *container) *container {
+	out := &container{key: a.key}
+	if a.bitmap == nil && b.bitmap == nil && a.n+b.n <= arrayMax {
+		out.array = slice.UnionSorted(a.array, b.array)
+		out.fix()
+		return out
+	}
+	x, y := a.words(), b.words()
+	out.bitmap = make([]uint64, bitmapWords)
+	for i := range out.bitmap {
+		out.bitmap[i] = x[i] | y[i]
+	}
+	out.fix()
+	return out
+}
+
+func andNotContainer(a, b *container) *container {
+	out := &container{key: a.key}
+	if a.bitmap == nil {
+		for _, v := range a.array {
+			if !b.contains(v) {
+				out.array = append(out.array, v)
+			}
+		}
+		out.fix()
+		return out
+	}
+	y := b.words()
+	out.bitmap = make([]uint64, bitmapWords)
+	for i := range out.bitmap {
+		out.bitmap[i] = a.bitmap[i] &^ y[i]
+	}
+	out.fix()
+	return out
+}
+
+// Bitmap is a compressed set of uint32 in the style of Roaring bitmaps.
+// Members are split by their high 16 bits into containers that are sorted
+// arrays while sparse and bitmaps once dense, so a handful of IDs spread over
+// the whole range takes a few bytes each instead of 512MB for a BitSet.
+// The zero value is an empty bitmap. It is not safe for concurrent writes.
+type Bitmap struct {
+	containers []*container // sorted by key
+}
+
+// NewBitmap returns a bitmap holding ids.
+func NewBitmap(ids ...uint32) *Bitmap {
+	b := &Bitmap{}
+	for _, id := range ids {
+		b.Add(id)
+	}
+	return b
+}
+
+func (b *Bitmap) find(key uint16) (int, bool) {
+	i := sort.Search(len(b.containers), func(i int) bool { return b.containers[i].key >= key })
+	return i, i < len(b.containers) && b.containers[i].key == key
+}
+
+// container returns the container of key, creating it if needed.
+func (b *Bitmap) container(key uint16) *container {
+	i, ok := b.find(key)
+	if !ok {
+		b.containers = append(b.containers, nil)
+		copy(b.containers[i+1:], b.containers[i:])
+		b.containers[i] = &container{key: key}
+	}
+	return b.containers[i]
+}
+
+// Add adds x to the bitmap and reports whether it was absent.
+func (b *Bitmap) Add(x uint32) bool {
+	return b.container(uint16(x >> 16)).add(uint16(x))
+}
+
+// AddRange adds every integer in [start, end].
+func (b *Bitmap) AddRange(start, end uint32) {
+	if start > end {
+		return
+	}
+	for key := start >> 16; key <= end>>16; key++ {
+		lo, hi := 0, 1<<16-1
+		if key == start>>16 {
+			lo = int(start & 0xffff)
+		}
+		if key == end>>16 {
+			hi = int(end & 0xffff)
+		}
+		b.container(uint16(key)).addRange(lo, hi)
+	}
+}
+
+// Remove removes x from the bitmap and reports whether it was present.
+func (b *Bitmap) Remove(x uint32) bool {
+	i, ok := b.find(uint16(x >> 16))
+	if !ok {
+		return false
+	}
+	c := b.containers[i]
+	if !c.remove(uint16(x)) {
+		return false
+	}
+	if c.n == 0 {
+		b.containers = append(b.containers[:i], b.containers[i+1:]...)
+	}
+	return true
+}
+
+// Contains reports whether x is in the bitmap.
+func (b *Bitmap) Contains(x uint32) bool {
+	i, ok := b.find(uint16(x >> 16))
+	return ok && b.containers[i].contains(uint16(x))
+}
+
+// Count returns the number of members.
+func (b *Bitmap) Count() int {
+	n := 0
+	for _, c := range b.containers {
+		n += c.n
+	}
+	return n
+}
+
+// IsEmpty reports whether the bitmap has no members.
+func (b *Bitmap) IsEmpty() bool {
+	return len(b.containers) == 0
+}
+
+// Range calls f for each member in ascending order until f returns false.
+func (b *Bitmap) Range(f func(x uint32) bool) {
+	for _, c := range b.containers {
+		high := uint32(c.key) << 16
+		if !c.each(func(v uint16) bool { return f(high | uint32(v)) }) {
+			return
+		}
+	}
+}
+
+// ToSlice returns the members in ascending order.
+func (b *Bitmap) ToSlice() []uint32 {
+	list := make([]uint32, 0, b.Count())
+	b.Range(func(x uint32) bool {
+		list = append(list, x)
+		return true
+	})
+	return list
+}
+
+// Ranges returns the members grouped like slice.MergeSortedAdjacent.
+func (b *Bitmap) Ranges() [][]uint32 {
+	return slice.MergeSortedAdjacent(b.ToSlice())
+}
+
+// BitmapFromRanges returns a bitmap holding the ranges produced by
+// slice.MergeSortedAdjacent.
+func BitmapFromRanges(ranges [][]uint32) *Bitmap {
+	b := &Bitmap{}
+	for _, r := range ranges {
+		if len(r) > 0 {
+			b.AddRange(r[0], r[len(r)-1])
+		}
+	}
+	return b
+}
+
+// Clone returns a deep copy of the bitmap.
+func (b *Bitmap) Clone() *Bitmap {
+	out := &Bitmap{containers: make([]*container, len(b.containers))}
+	for i, c := range b.containers {
+		out.containers[i] = c.clone()
+	}
+	return out
+}
+
+// And returns the members found in both b and o.
+func (b *Bitmap) And(o *Bitmap) *Bitmap {
+	out := &Bitmap{}
+	i, j := 0, 0
+	for i < len(b.containers) && j < len(o.containers) {
+		x, y := b.containers[i], o.containers[j]
+		switch {
+		case x.key < y.key:
+			i++
+		case x.key > y.key:
+			j++
+		default:
+			if c := andContainer(x, y); c.n > 0 {
+				out.containers = append(out.containers, c)
+			}
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// Or returns the members found in b or o.
+func (b *Bitmap) Or(o *Bitmap) *Bitmap {
+	out := &Bitmap{}
+	i, j := 0, 0
+	for i < len(b.containers) || j < len(o.containers) {
+		switch {
+		case j == len(o.containers) || (i < len(b.containers) && b.containers[i].key < o.containers[j].key):
+			out.containers = append(out.containers, b.containers[i].clone())
+			i++
+		case i == len(b.containers) || b.containers[i].key > o.containers[j].key:
+			out.containers = append(out.containers, o.containers[j].clone())
+			j++
+		default:
+			out.containers = append(out.containers, orContainer(b.containers[i], o.containers[j]))
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// AndNot returns the members of b that are not in o.
+func (b *Bitmap) AndNot(o *Bitmap) *Bitmap {
+	out := &Bitmap{}
+	for _, c := range b.containers {
+		j, ok := o.find(c.key)
+		if !ok {
+			out.containers = append(out.containers, c.clone())
+			continue
+		}
+		if d := andNotContainer(c, o.containers[j]); d.n > 0 {
+			out.containers = append(out.containers, d)
+		}
+	}
+	return out
+}
+
+// Xor returns the members found in exactly one of b and o.
+func (b *Bitmap) Xor(o *Bitmap) *Bitmap {
+	return b.AndNot(o).Or(o.AndNot(b))
+}
+
+// MarshalBinary encodes the bitmap as
+//
+//	magic uint32 | container count uint32 |
+//	per container: key uint16 | type uint8 | cardinality uint32 | payload
+//
+// in little endian, where the payload is cardinality uint16 for an array
+// container and 1024 uint64 for a bitmap container.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	size := headerLength
+	for _, c := range b.containers {
+		size += 7
+		if c.bitmap != nil {
+			size += bitmapWords * 8
+		} else {
+			size += len(c.array) * 2
+		}
+	}
+
+	buf := make([]byte, 0, size)
+	buf = binary.LittleEndian.AppendUint32(buf, bitmapMagic)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(b.containers)))
+	for _, c := range b.containers {
+		buf = binary.LittleEndian.AppendUint16(buf, c.key)
+		if c.bitmap != nil {
+			buf = append(buf, typeBitmap)
+		} else {
+			buf = append(buf, typeArray)
+		}
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(c.n))
+		if c.bitmap != nil {
+			for _, w := range c.bitmap {
+				buf = binary.LittleEndian.AppendUint64(buf, w)
+			}
+			continue
+		}
+		for _, v := range c.array {
+			buf = binary.LittleEndian.AppendUint16(buf, v)
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the content of b with data produced by
+// MarshalBinary. It returns ErrInvalidBitmap if data is malformed.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < headerLength || binary.LittleEndian.Uint32(data) != bitmapMagic {
+		return ErrInvalidBitmap
+	}
+	count := binary.LittleEndian.Uint32(data[4:])
+	data = data[headerLength:]
+
+	containers := make([]*container, 0)
+	for i := uint32(0); i < count; i++ {
+		if len(data) < 7 {
+			return ErrInvalidBitmap
+		}
+		c := &container{key: binary.LittleEndian.Uint16(data)}
+		typ := data[2]
+		n := int(binary.LittleEndian.Uint32(data[3:]))
+		data = data[7:]
+		if n == 0 || n > 1<<16 || (i > 0 && c.key <= containers[i-1].key) {
+			return ErrInvalidBitmap
+		}
+
+		switch typ {
+		case typeArray:
+			if n > arrayMax || len(data) < n*2 {
+				return ErrInvalidBitmap
+			}
+			c.array = make([]uint16, n)
+			for j := range c.array {
+				c.array[j] = binary.LittleEndian.Uint16(data[j*2:])
+				if j > 0 && c.array[j] <= c.array[j-1] {
+					return ErrInvalidBitmap
+				}
+			}
+			data = data[n*2:]
+		case typeBitmap:
+			if len(data) < bitmapWords*8 {
+				return ErrInvalidBitmap
+			}
+			c.bitmap = make([]uint64, bitmapWords)
+			for j := range c.bitmap {
+				c.bitmap[j] = binary.LittleEndian.Uint64(data[j*8:])
+			}
+			data = data[bitmapWords*8:]
+		default:
+			return ErrInvalidBitmap
+		}
+		c.fix()
+		if c.n != n {
+			return ErrInvalidBitmap
+		}
+		containers = append(containers, c)
+	}
+	if len(data) != 0 {
+		return ErrInvalidBitmap
+	}
+	b.containers = containers
+	return nil
+}
@@ -0,0 +1,192 @@
+package bitset
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestBitmapAddRemove(t *testing.T) {
+	b := NewBitmap(5, 1<<20, 3, 5, 0xffffffff)
+	if b.Count() != 4 {
+		t.Fatalf("Count() = %d, want 4", b.Count())
+	}
+	if got := b.ToSlice(); !reflect.DeepEqual(got, []uint32{3, 5, 1 << 20, 0xffffffff}) {
+		t.Errorf("ToSlice() = %v", got)
+	}
+	if !b.Contains(1<<20) || b.Contains(4) {
+		t.Error("Contains() returned wrong membership")
+	}
+	if !b.Remove(1<<20) || b.Remove(1<<20) {
+		t.Error("Remove() should succeed once")
+	}
+	if len(b.containers) != 2 {
+		t.Errorf("empty container kept, %d containers", len(b.containers))
+	}
+}
+
+func TestBitmapDenseContainer(t *testing.T) {
+	b := &Bitmap{}
+	b.AddRange(0, 9999)
+	if b.containers[0].bitmap == nil {
+		t.Fatal("container should switch to a bitmap above 4096 members")
+	}
+	for i := uint32(0); i < 6000; i++ {
+		b.Remove(i)
+	}
+	if b.containers[0].bitmap == nil {
+		t.Error("container should stay a bitmap just below 4096 members")
+	}
+	for i := uint32(6000); i < 8000; i++ {
+		b.Remove(i)
+	}
+	if b.containers[0].bitmap != nil {
+		t.Error("container should switch back to an array")
+	}
+	if b.Count() != 2000 || !b.Contains(8000) || b.Contains(7999) {
+		t.Errorf("Count() = %d after removals", b.Count())
+	}
+}
+
+func TestBitmapAddRange(t *testing.T) {
+	b := NewBitmap(5, 70000, 1<<20)
+	b.AddRange(3, 10)
+	b.AddRange(65530, 200000)
+	b.AddRange(0xfffffff0, 0xffffffff)
+	want := map[uint32]bool{70000: true, 1 << 20: true}
+	for _, r := range [][2]uint32{{3, 10}, {65530, 200000}, {0xfffffff0, 0xffffffff}} {
+		for x := r[0]; ; x++ {
+			want[x] = true
+			if x == r[1] {
+				break
+			}
+		}
+	}
+	if got := b.ToSlice(); !reflect.DeepEqual(got, sortedKeys(want)) {
+		t.Errorf("AddRange() gave %d members, want %d", len(got), len(want))
+	}
+	for _, c := range b.containers {
+		if (c.bitmap != nil) != (c.n > arrayMax) {
+			t.Errorf("container %d with %d members has the wrong type", c.key, c.n)
+		}
+	}
+
+	full := &Bitmap{}
+	full.AddRange(0, 3<<16-1)
+	if full.Count() != 3<<16 || len(full.containers) != 3 {
+		t.Errorf("full containers Count() = %d", full.Count())
+	}
+}
+
+func toSet(list []uint32) map[uint32]bool {
+	m := make(map[uint32]bool, len(list))
+	for _, v := range list {
+		m[v] = true
+	}
+	return m
+}
+
+func sortedKeys(m map[uint32]bool) []uint32 {
+	list := make([]uint32, 0, len(m))
+	for k := range m {
+		list = append(list, k)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+func TestBitmapAlgebra(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	gen := func(n int, dense bool) []uint32 {
+		list := make([]uint32, n)
+		for i := range list {
+			if dense {
+				list[i] = uint32(r.Intn(1 << 17))
+			} else {
+				list[i] = r.Uint32()
+			}
+		}
+		return list
+	}
+	x := append(gen(20000, true), gen(500, false)...)
+	y := append(gen(3000, true), gen(500, false)...)
+	a, b := NewBitmap(x...), NewBitmap(y...)
+	sa, sb := toSet(x), toSet(y)
+
+	and, or, xor, andNot := map[uint32]bool{}, map[uint32]bool{}, map[uint32]bool{}, map[uint32]bool{}
+	for v := range sa {
+		or[v] = true
+		if sb[v] {
+			and[v] = true
+		} else {
+			xor[v] = true
+			andNot[v] = true
+		}
+	}
+	for v := range sb {
+		or[v] = true
+		if !sa[v] {
+			xor[v] = true
+		}
+	}
+
+	cases := []struct {
+		name string
+		got  *Bitmap
+		want map[uint32]bool
+	}{
+		{"And", a.And(b), and},
+		{"Or", a.Or(b), or},
+		{"Xor", a.Xor(b), xor},
+		{"AndNot", a.AndNot(b), andNot},
+	}
+	for _, c := range cases {
+		got := c.got.ToSlice()
+		if want := sortedKeys(c.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %d members, want %d", c.name, len(got), len(want))
+		}
+		if c.got.Count() != len(c.want) {
+			t.Errorf("%s: Count() = %d, want %d", c.name, c.got.Count(), len(c.want))
+		}
+	}
+	if a.Count() != len(sa) {
+		t.Error("operands changed")
+	}
+}
+
+func TestBitmapMarshal(t *testing.T) {
+	b := NewBitmap(1, 2, 3, 1<<30)
+	b.AddRange(70000, 80000)
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Bitmap
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.ToSlice(), b.ToSlice()) {
+		t.Error("round trip changed the members")
+	}
+
+	empty, _ := (&Bitmap{}).MarshalBinary()
+	if err := got.UnmarshalBinary(empty); err != nil || !got.IsEmpty() {
+		t.Errorf("empty round trip: %v", err)
+	}
+
+	for _, bad := range [][]byte{nil, []byte("garbage!"), data[:len(data)-1], append(data, 0)} {
+		if err := got.UnmarshalBinary(bad); !errors.Is(err, ErrInvalidBitmap) {
+			t.Errorf("UnmarshalBinary(%d bytes) = %v, want ErrInvalidBitmap", len(bad), err)
+		}
+	}
+}
+
+func TestBitmapRanges(t *testing.T) {
+	b := BitmapFromRanges([][]uint32{{1, 3}, {5}, {65535, 65537}})
+	want := [][]uint32{{1, 3}, {5}, {65535, 65537}}
+	if got := b.Ranges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Ranges() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package interval
+
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Span is a closed range [Start, End] of a RangeMap and its value.
+type Span[K constraints.Integer, V comparable] struct {
+	Start, End K
+	Value      V
+}
+
+// RangeMap maps non-overlapping integer ranges to values, such as IP ranges
+// from netx.IPv4ToLong to locations or unix times to schedules. Setting a
+// range overwrites whatever it covers, and adjacent ranges holding equal
+// values are merged, so [1, 5] and [6, 9] set to "a" become [1, 9].
+// Lookups are O(log n), updates O(n) in the worst case.
+// It is not safe for concurrent writes.
+type RangeMap[K constraints.Integer, V comparable] struct {
+	spans []Span[K, V] // sorted and non-overlapping
+}
+
+// NewRangeMap returns an empty range map.
+func NewRangeMap[K constraints.Integer, V comparable]() *RangeMap[K, V] {
+	return &RangeMap[K, V]{}
+}
+
+// Len returns the number of spans after merging.
+func (m *RangeMap[K, V]) Len() int {
+	return len(m.spans)
+}
+
+// Get returns the value of the span containing k, false if none does.
+func (m *RangeMap[K, V]) Get(k K) (V, bool) {
+	i := sort.Search(len(m.spans), func(i int) bool { return m.spans[i].End >= k })
+	if i < len(m.spans) && m.spans[i].Start <= k {
+		return m.spans[i].Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// GetSpan returns the span containing k, false if none does.
+func (m *RangeMap[K, V]) GetSpan(k K) (Span[K, V], bool) {
+	i := sort.Search(len(m.spans), func(i int) bool { return m.spans[i].End >= k })
+	if i < len(m.spans) && m.spans[i].Start <= k {
+		return m.spans[i], true
+	}
+	return Span[K, V]{}, false
+}
+
+// Set maps every key in [start, end] to v, replacing previous values.
+// start and end are swapped if start is greater.
+func (m *RangeMap[K, V]) Set(start, end K, v V) {
+	if end < start {
+		start, end = end, start
+	}
+	m.splice(start, end, &Span[K, V]{Start: start, End: end, Value: v})
+}
+
+// Delete unmaps every key in [start, end].
+func (m *RangeMap[K, V]) Delete(start, end K) {
+	if end < start {
+		start, end = end, start
+	}
+	m.splice(start, end, nil)
+}
+
+// splice replaces the part of the map covering [start, end] with mid,
+// keeping the uncovered ends of partially covered spans.
+func (m *RangeMap[K, V]) splice(start, end K, mid *Span[K, V]) {
+	i := sort.Search(len(m.spans), func(i int) bool { return m.spans[i].End >= start })
+	j := sort.Search(len(m.spans), func(j int) bool { return m.spans[j].Start > end })
+
+	repl := make([]Span[K, V], 0, 3)
+	if i < j && m.spans[i].Start < start {
+		left := m.spans[i]
+		left.End = start - 1
+		repl = append(repl, left)
+	}
+	if mid != nil {
+		repl = append(repl, *mid)
+	}
+	if i < j && m.spans[j-1].End > end {
+		right := m.spans[j-1]
+		right.Start = end + 1
+		repl = append(repl, right)
+	}
+
+	spans := make([]Span[K, V], 0, len(m.spans)-(j-i)+len(repl))
+	spans = append(spans, m.spans[:i]...)
+	spans = append(spans, repl...)
+	spans = append(spans, m.spans[j:]...)
+	m.spans = spans
+
+	// only the spans around the replacement can have become mergeable
+	lo, hi := i-1, i+len(repl)
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > len(m.spans)-1 {
+		hi = len(m.spans) - 1
+	}
+	m.mergeAdjacent(lo, hi)
+}
+
+// mergeAdjacent merges touching spans with equal values within spans[lo:hi+1].
+func (m *RangeMap[K, V]) mergeAdjacent(lo, hi int) {
+	if lo >= hi {
+		return
+	}
+	out := m.spans[:lo+1]
+	for k := lo + 1; k <= hi; k++ {
+		last, s := &out[len(out)-1], m.spans[k]
+		// s.Start > last.End, so s.Start-1 cannot underflow
+		if last.Value == s.Value && s.Start-1 == last.End {
+			last.End = s.End
+			continue
+		}
+		out = append(out, s)
+	}
+	m.spans = append(out, m.spans[hi+1:]...)
+}
+
+// Range calls f for each span in ascending order until f returns false.
+func (m *RangeMap[K, V]) Range(f func(s Span[K, V]) bool) {
+	for _, s := range m.spans {
+		if !f(s) {
+			return
+		}
+	}
+}
+
+// Spans returns a copy of the spans in ascending order.
+func (m *RangeMap[K, V]) Spans() []Span[K, V] {
+	spans := make([]Span[K, V], len(m.spans))
+	copy(spans, m.spans)
+	return spans
+}
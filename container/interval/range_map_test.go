@@ -0,0 +1,81 @@
+package interval
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hy-shine/gotiny/netx"
+)
+
+func TestRangeMapSetGet(t *testing.T) {
+	m := NewRangeMap[int, string]()
+	m.Set(1, 10, "a")
+	m.Set(5, 6, "b")
+	want := []Span[int, string]{{1, 4, "a"}, {5, 6, "b"}, {7, 10, "a"}}
+	if got := m.Spans(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Spans() = %v, want %v", got, want)
+	}
+
+	for k, v := range map[int]string{1: "a", 4: "a", 5: "b", 6: "b", 7: "a", 10: "a"} {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Errorf("Get(%d) = %q, %v, want %q", k, got, ok, v)
+		}
+	}
+	for _, k := range []int{0, 11, -5} {
+		if _, ok := m.Get(k); ok {
+			t.Errorf("Get(%d) should miss", k)
+		}
+	}
+
+	// overwriting the middle with "a" merges everything back
+	m.Set(5, 6, "a")
+	if got := m.Spans(); !reflect.DeepEqual(got, []Span[int, string]{{1, 10, "a"}}) {
+		t.Errorf("Spans() after merge = %v", got)
+	}
+	m.Set(11, 20, "a")
+	m.Set(30, 40, "a")
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+}
+
+func TestRangeMapDelete(t *testing.T) {
+	m := NewRangeMap[int, int]()
+	m.Set(0, 100, 1)
+	m.Delete(20, 30)
+	m.Delete(90, 200)
+	want := []Span[int, int]{{0, 19, 1}, {31, 89, 1}}
+	if got := m.Spans(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Spans() = %v, want %v", got, want)
+	}
+	if s, ok := m.GetSpan(50); !ok || s.Start != 31 || s.End != 89 {
+		t.Errorf("GetSpan(50) = %v, %v", s, ok)
+	}
+}
+
+func TestRangeMapBounds(t *testing.T) {
+	m := NewRangeMap[uint8, bool]()
+	m.Set(0, 10, true)
+	m.Set(11, math.MaxUint8, true)
+	m.Set(math.MaxUint8, math.MaxUint8, false)
+	want := []Span[uint8, bool]{{0, 254, true}, {255, 255, false}}
+	if got := m.Spans(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Spans() = %v, want %v", got, want)
+	}
+}
+
+func TestRangeMapIP(t *testing.T) {
+	m := NewRangeMap[uint32, string]()
+	m.Set(netx.IPv4StrToLong("10.0.0.0"), netx.IPv4StrToLong("10.255.255.255"), "private")
+	m.Set(netx.IPv4StrToLong("1.0.1.0"), netx.IPv4StrToLong("1.0.3.255"), "CN")
+	if v, _ := m.Get(netx.IPv4StrToLong("10.1.2.3")); v != "private" {
+		t.Errorf("Get(10.1.2.3) = %q", v)
+	}
+	if v, _ := m.Get(netx.IPv4StrToLong("1.0.2.8")); v != "CN" {
+		t.Errorf("Get(1.0.2.8) = %q", v)
+	}
+	if _, ok := m.Get(netx.IPv4StrToLong("8.8.8.8")); ok {
+		t.Error("Get(8.8.8.8) should miss")
+	}
+}
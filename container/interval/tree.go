@@ -0,0 +1,230 @@
+package interval
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// Interval is the closed range [Start, End].
+type Interval[K constraints.Ordered] struct {
+	Start, End K
+}
+
+// Contains reports whether k lies in the interval.
+func (i Interval[K]) Contains(k K) bool {
+	return i.Start <= k && k <= i.End
+}
+
+// Overlaps reports whether both intervals share at least one point.
+func (i Interval[K]) Overlaps(o Interval[K]) bool {
+	return i.Start <= o.End && o.Start <= i.End
+}
+
+// Entry is an interval stored in a Tree with its value.
+type Entry[K constraints.Ordered, V any] struct {
+	Interval Interval[K]
+	Value    V
+}
+
+type node[K constraints.Ordered, V any] struct {
+	entry       Entry[K, V]
+	max         K // largest End in this subtree
+	prio        uint64
+	left, right *node[K, V]
+}
+
+func (n *node[K, V]) update() {
+	n.max = n.entry.Interval.End
+	if n.left != nil && n.left.max > n.max {
+		n.max = n.left.max
+	}
+	if n.right != nil && n.right.max > n.max {
+		n.max = n.right.max
+	}
+}
+
+// less orders intervals by Start, then End.
+func less[K constraints.Ordered](a, b Interval[K]) bool {
+	return a.Start < b.Start || (a.Start == b.Start && a.End < b.End)
+}
+
+// Tree is an interval tree: a treap ordered by interval start where every
+// node also keeps the largest end of its subtree, so overlap and stabbing
+// queries skip the branches that cannot match. Insert and Delete take
+// O(log n), queries O(log n + m) for m results. Intervals may overlap and
+// may be added more than once. It is not safe for concurrent writes.
+type Tree[K constraints.Ordered, V any] struct {
+	root *node[K, V]
+	size int
+	seed uint64
+}
+
+// NewTree returns an empty interval tree.
+func NewTree[K constraints.Ordered, V any]() *Tree[K, V] {
+	return &Tree[K, V]{}
+}
+
+// Len returns the number of intervals.
+func (t *Tree[K, V]) Len() int {
+	return t.size
+}
+
+// nextPrio returns the next treap priority from a splitmix64 sequence.
+func (t *Tree[K, V]) nextPrio() uint64 {
+	t.seed += 0x9e3779b97f4a7c15
+	z := t.seed
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Insert adds the interval [start, end] with value v.
+// start and end are swapped if start is greater.
+func (t *Tree[K, V]) Insert(start, end K, v V) {
+	if end < start {
+		start, end = end, start
+	}
+	n := &node[K, V]{entry: Entry[K, V]{Interval: Interval[K]{start, end}, Value: v}, max: end, prio: t.nextPrio()}
+	t.root = insert(t.root, n)
+	t.size++
+}
+
+func insert[K constraints.Ordered, V any](root, n *node[K, V]) *node[K, V] {
+	if root == nil {
+		return n
+	}
+	if less(n.entry.Interval, root.entry.Interval) {
+		root.left = insert(root.left, n)
+		if root.left.prio > root.prio {
+			root = rotateRight(root)
+		}
+	} else {
+		root.right = insert(root.right, n)
+		if root.right.prio > root.prio {
+			root = rotateLeft(root)
+		}
+	}
+	root.update()
+	return root
+}
+
+func rotateRight[K constraints.Ordered, V any](n *node[K, V]) *node[K, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
+
+func rotateLeft[K constraints.Ordered, V any](n *node[K, V]) *node[K, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+// Delete removes one interval equal to [start, end] and reports
+// whether it was found.
+func (t *Tree[K, V]) Delete(start, end K) bool {
+	if end < start {
+		start, end = end, start
+	}
+	var ok bool
+	t.root, ok = remove(t.root, Interval[K]{start, end})
+	if ok {
+		t.size--
+	}
+	return ok
+}
+
+func remove[K constraints.Ordered, V any](n *node[K, V], iv Interval[K]) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var ok bool
+	switch {
+	case less(iv, n.entry.Interval):
+		n.left, ok = remove(n.left, iv)
+	case less(n.entry.Interval, iv):
+		n.right, ok = remove(n.right, iv)
+	default:
+		return merge(n.left, n.right), true
+	}
+	if ok {
+		n.update()
+	}
+	return n, ok
+}
+
+// merge joins two treaps where every interval of a sorts before those of b.
+func merge[K constraints.Ordered, V any](a, b *node[K, V]) *node[K, V] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.prio > b.prio:
+		a.right = merge(a.right, b)
+		a.update()
+		return a
+	default:
+		b.left = merge(a, b.left)
+		b.update()
+		return b
+	}
+}
+
+// Stab returns the intervals containing k, ordered by start.
+func (t *Tree[K, V]) Stab(k K) []Entry[K, V] {
+	return t.Overlap(k, k)
+}
+
+// Overlap returns the intervals sharing at least one point with
+// [start, end], ordered by start.
+func (t *Tree[K, V]) Overlap(start, end K) []Entry[K, V] {
+	var list []Entry[K, V]
+	t.overlap(t.root, Interval[K]{start, end}, func(e Entry[K, V]) bool {
+		list = append(list, e)
+		return true
+	})
+	return list
+}
+
+// AnyOverlap reports whether some interval shares a point with [start, end].
+func (t *Tree[K, V]) AnyOverlap(start, end K) bool {
+	found := false
+	t.overlap(t.root, Interval[K]{start, end}, func(Entry[K, V]) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+func (t *Tree[K, V]) overlap(n *node[K, V], q Interval[K], f func(e Entry[K, V]) bool) bool {
+	if n == nil || n.max < q.Start {
+		return true
+	}
+	if !t.overlap(n.left, q, f) {
+		return false
+	}
+	if n.entry.Interval.Start > q.End {
+		// this node and its right subtree start after the query
+		return true
+	}
+	if n.entry.Interval.Overlaps(q) && !f(n.entry) {
+		return false
+	}
+	return t.overlap(n.right, q, f)
+}
+
+// Range calls f for each interval ordered by start until f returns false.
+func (t *Tree[K, V]) Range(f func(e Entry[K, V]) bool) {
+	each(t.root, f)
+}
+
+func each[K constraints.Ordered, V any](n *node[K, V], f func(e Entry[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return each(n.left, f) && f(n.entry) && each(n.right, f)
+}
@@ -0,0 +1,98 @@
+package interval
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestTreeQueries(t *testing.T) {
+	tr := NewTree[int, string]()
+	tr.Insert(1, 5, "a")
+	tr.Insert(3, 8, "b")
+	tr.Insert(10, 12, "c")
+	tr.Insert(7, 6, "d") // swapped to [6, 7]
+
+	values := func(list []Entry[int, string]) []string {
+		var out []string
+		for _, e := range list {
+			out = append(out, e.Value)
+		}
+		return out
+	}
+	if got := values(tr.Stab(4)); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Stab(4) = %v", got)
+	}
+	if got := values(tr.Stab(9)); got != nil {
+		t.Errorf("Stab(9) = %v", got)
+	}
+	if got := values(tr.Overlap(7, 10)); !reflect.DeepEqual(got, []string{"b", "d", "c"}) {
+		t.Errorf("Overlap(7, 10) = %v", got)
+	}
+	if !tr.AnyOverlap(12, 20) || tr.AnyOverlap(13, 20) {
+		t.Error("AnyOverlap returned wrong result")
+	}
+
+	if !tr.Delete(3, 8) || tr.Delete(3, 8) {
+		t.Error("Delete should succeed once")
+	}
+	if tr.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", tr.Len())
+	}
+	if got := values(tr.Stab(4)); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Stab(4) after Delete = %v", got)
+	}
+}
+
+func TestTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	tr := NewTree[int, int]()
+	var all []Interval[int]
+	for i := 0; i < 2000; i++ {
+		s := r.Intn(10000)
+		iv := Interval[int]{s, s + r.Intn(100)}
+		all = append(all, iv)
+		tr.Insert(iv.Start, iv.End, i)
+	}
+	for i := 0; i < 500; i++ {
+		k := r.Intn(len(all))
+		if !tr.Delete(all[k].Start, all[k].End) {
+			t.Fatalf("Delete(%v) failed", all[k])
+		}
+		all = append(all[:k], all[k+1:]...)
+	}
+
+	for i := 0; i < 200; i++ {
+		q := Interval[int]{r.Intn(10000), 0}
+		q.End = q.Start + r.Intn(50)
+		var want []Interval[int]
+		for _, iv := range all {
+			if iv.Overlaps(q) {
+				want = append(want, iv)
+			}
+		}
+		sort.Slice(want, func(i, j int) bool { return less(want[i], want[j]) })
+		var got []Interval[int]
+		for _, e := range tr.Overlap(q.Start, q.End) {
+			got = append(got, e.Interval)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Overlap(%v) = %d intervals, want %d", q, len(got), len(want))
+		}
+	}
+
+	prev := Interval[int]{-1, -1}
+	count := 0
+	tr.Range(func(e Entry[int, int]) bool {
+		if less(e.Interval, prev) {
+			t.Fatalf("Range out of order: %v after %v", e.Interval, prev)
+		}
+		prev = e.Interval
+		count++
+		return true
+	})
+	if count != len(all) || tr.Len() != len(all) {
+		t.Errorf("Range visited %d, Len() = %d, want %d", count, tr.Len(), len(all))
+	}
+}
@@ -0,0 +1,390 @@
+package persistent
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math"
+	"math/bits"
+	"reflect"
+)
+
+var seed = maphash.MakeSeed()
+
+// Hash is the default hash function of Map. It hashes strings, booleans,
+// numbers and pointers, including named types of those kinds, and panics
+// for other keys such as structs: no generic hash agrees with == on them,
+// so pass a dedicated function to NewMapFunc for such keys.
+func Hash[K comparable](k K) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	switch v := any(k).(type) {
+	case string:
+		h.WriteString(v)
+	case int:
+		return mix(uint64(v))
+	case int8:
+		return mix(uint64(v))
+	case int16:
+		return mix(uint64(v))
+	case int32:
+		return mix(uint64(v))
+	case int64:
+		return mix(uint64(v))
+	case uint:
+		return mix(uint64(v))
+	case uint8:
+		return mix(uint64(v))
+	case uint16:
+		return mix(uint64(v))
+	case uint32:
+		return mix(uint64(v))
+	case uint64:
+		return mix(v)
+	case uintptr:
+		return mix(uint64(v))
+	case float32:
+		return hashFloat(float64(v))
+	case float64:
+		return hashFloat(v)
+	case bool:
+		if v {
+			return mix(1)
+		}
+		return mix(0)
+	default:
+		return hashValue(reflect.ValueOf(v), &h)
+	}
+	return h.Sum64()
+}
+
+// hashValue hashes named types by their kind.
+func hashValue(rv reflect.Value, h *maphash.Hash) uint64 {
+	switch rv.Kind() {
+	case reflect.String:
+		h.WriteString(rv.String())
+		return h.Sum64()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mix(uint64(rv.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mix(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return hashFloat(rv.Float())
+	case reflect.Bool:
+		if rv.Bool() {
+			return mix(1)
+		}
+		return mix(0)
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		return mix(uint64(rv.Pointer()))
+	}
+	panic(fmt.Sprintf("persistent: no default hash for %s keys, use NewMapFunc", rv.Type()))
+}
+
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		// +0 and -0 are equal keys
+		return mix(0)
+	}
+	return mix(math.Float64bits(f))
+}
+
+// mix spreads the bits of x so that consecutive numbers use different branches.
+func mix(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+type entry[K comparable, V any] struct {
+	hash uint64
+	key  K
+	val  V
+}
+
+// slot holds either a child node or an entry.
+type slot[K comparable, V any] struct {
+	child *hnode[K, V]
+	entry entry[K, V]
+}
+
+// hnode is a node of a hash array mapped trie. Below the last level all
+// entries share the same hash and are kept in collisions.
+type hnode[K comparable, V any] struct {
+	bitmap     uint32
+	slots      []slot[K, V]
+	collisions []entry[K, V]
+}
+
+const maxShift = 64
+
+func (n *hnode[K, V]) empty() bool {
+	return n.bitmap == 0 && len(n.collisions) == 0
+}
+
+func (n *hnode[K, V]) get(shift uint, hash uint64, key K) (V, bool) {
+	for shift < maxShift {
+		bit := uint32(1) << ((hash >> shift) & mask)
+		if n.bitmap&bit == 0 {
+			var zero V
+			return zero, false
+		}
+		s := &n.slots[bits.OnesCount32(n.bitmap&(bit-1))]
+		if s.child == nil {
+			if s.entry.key == key {
+				return s.entry.val, true
+			}
+			var zero V
+			return zero, false
+		}
+		n, shift = s.child, shift+nodeBits
+	}
+	for i := range n.collisions {
+		if n.collisions[i].key == key {
+			return n.collisions[i].val, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// with returns a copy of n holding e and whether e.key was not present before.
+func (n *hnode[K, V]) with(shift uint, e entry[K, V]) (*hnode[K, V], bool) {
+	if shift >= maxShift {
+		collisions := make([]entry[K, V], len(n.collisions), len(n.collisions)+1)
+		copy(collisions, n.collisions)
+		for i := range collisions {
+			if collisions[i].key == e.key {
+				collisions[i] = e
+				return &hnode[K, V]{collisions: collisions}, false
+			}
+		}
+		return &hnode[K, V]{collisions: append(collisions, e)}, true
+	}
+
+	bit := uint32(1) << ((e.hash >> shift) & mask)
+	pos := bits.OnesCount32(n.bitmap & (bit - 1))
+	if n.bitmap&bit == 0 {
+		slots := make([]slot[K, V], len(n.slots)+1)
+		copy(slots, n.slots[:pos])
+		slots[pos] = slot[K, V]{entry: e}
+		copy(slots[pos+1:], n.slots[pos:])
+		return &hnode[K, V]{bitmap: n.bitmap | bit, slots: slots}, true
+	}
+
+	s := n.slots[pos]
+	added := true
+	switch {
+	case s.child != nil:
+		s.child, added = s.child.with(shift+nodeBits, e)
+	case s.entry.key == e.key:
+		s.entry, added = e, false
+	default:
+		// two keys in one branch, push both a level down
+		child, _ := (&hnode[K, V]{}).with(shift+nodeBits, s.entry)
+		s.child, _ = child.with(shift+nodeBits, e)
+		s.entry = entry[K, V]{}
+	}
+	slots := make([]slot[K, V], len(n.slots))
+	copy(slots, n.slots)
+	slots[pos] = s
+	return &hnode[K, V]{bitmap: n.bitmap, slots: slots}, added
+}
+
+// without returns a copy of n without key, or n itself if key is absent.
+func (n *hnode[K, V]) without(shift uint, hash uint64, key K) (*hnode[K, V], bool) {
+	if shift >= maxShift {
+		for i := range n.collisions {
+			if n.collisions[i].key == key {
+				collisions := make([]entry[K, V], 0, len(n.collisions)-1)
+				collisions = append(collisions, n.collisions[:i]...)
+				collisions = append(collisions, n.collisions[i+1:]...)
+				return &hnode[K, V]{collisions: collisions}, true
+			}
+		}
+		return n, false
+	}
+
+	bit := uint32(1) << ((hash >> shift) & mask)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	pos := bits.OnesCount32(n.bitmap & (bit - 1))
+	s := n.slots[pos]
+	if s.child == nil {
+		if s.entry.key != key {
+			return n, false
+		}
+		slots := make([]slot[K, V], 0, len(n.slots)-1)
+		slots = append(slots, n.slots[:pos]...)
+		slots = append(slots, n.slots[pos+1:]...)
+		return &hnode[K, V]{bitmap: n.bitmap &^ bit, slots: slots}, true
+	}
+
+	child, removed := s.child.without(shift+nodeBits, hash, key)
+	if !removed {
+		return n, false
+	}
+	if child.empty() {
+		slots := make([]slot[K, V], 0, len(n.slots)-1)
+		slots = append(slots, n.slots[:pos]...)
+		slots = append(slots, n.slots[pos+1:]...)
+		return &hnode[K, V]{bitmap: n.bitmap &^ bit, slots: slots}, true
+	}
+	if e, ok := child.single(); ok {
+		// pull a lone entry back up so lookups stay short
+		s = slot[K, V]{entry: e}
+	} else {
+		s.child = child
+	}
+	slots := make([]slot[K, V], len(n.slots))
+	copy(slots, n.slots)
+	slots[pos] = s
+	return &hnode[K, V]{bitmap: n.bitmap, slots: slots}, true
+}
+
+// single returns the only entry of n if n holds exactly one entry and no child.
+func (n *hnode[K, V]) single() (entry[K, V], bool) {
+	if len(n.collisions) == 1 {
+		return n.collisions[0], true
+	}
+	if len(n.slots) == 1 && n.slots[0].child == nil {
+		return n.slots[0].entry, true
+	}
+	return entry[K, V]{}, false
+}
+
+func (n *hnode[K, V]) each(f func(k K, v V) bool) bool {
+	for i := range n.slots {
+		s := &n.slots[i]
+		if s.child != nil {
+			if !s.child.each(f) {
+				return false
+			}
+		} else if !f(s.entry.key, s.entry.val) {
+			return false
+		}
+	}
+	for i := range n.collisions {
+		if !f(n.collisions[i].key, n.collisions[i].val) {
+			return false
+		}
+	}
+	return true
+}
+
+// Map is an immutable hash map. With and Without return a new Map sharing
+// all untouched branches with the old one, so both versions stay valid and
+// can be read from any goroutine.
+//
+// It is a hash array mapped trie with 32-way branching, so lookups and
+// updates are O(log32 n). The zero value is an empty map using Hash.
+type Map[K comparable, V any] struct {
+	root *hnode[K, V]
+	size int
+	hash func(K) uint64
+}
+
+// NewMap returns an empty map using Hash, see it for the supported keys.
+func NewMap[K comparable, V any]() Map[K, V] {
+	return Map[K, V]{}
+}
+
+// NewMapFunc returns an empty map using hash, which must return the same
+// value for equal keys.
+func NewMapFunc[K comparable, V any](hash func(K) uint64) Map[K, V] {
+	return Map[K, V]{hash: hash}
+}
+
+// FromMap returns a map holding the entries of m.
+func FromMap[K comparable, V any](m map[K]V) Map[K, V] {
+	var pm Map[K, V]
+	for k, v := range m {
+		pm = pm.With(k, v)
+	}
+	return pm
+}
+
+func (m Map[K, V]) hashOf(k K) uint64 {
+	if m.hash == nil {
+		return Hash(k)
+	}
+	return m.hash(k)
+}
+
+// Len returns the number of entries.
+func (m Map[K, V]) Len() int {
+	return m.size
+}
+
+// Get returns the value of key, false if key is absent.
+func (m Map[K, V]) Get(key K) (V, bool) {
+	if m.root == nil {
+		var zero V
+		return zero, false
+	}
+	return m.root.get(0, m.hashOf(key), key)
+}
+
+// Has reports whether key is present.
+func (m Map[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// With returns a map with key set to val.
+func (m Map[K, V]) With(key K, val V) Map[K, V] {
+	root := m.root
+	if root == nil {
+		root = &hnode[K, V]{}
+	}
+	root, added := root.with(0, entry[K, V]{hash: m.hashOf(key), key: key, val: val})
+	if added {
+		m.size++
+	}
+	m.root = root
+	return m
+}
+
+// Without returns a map without key, or m itself if key is absent.
+func (m Map[K, V]) Without(key K) Map[K, V] {
+	if m.root == nil {
+		return m
+	}
+	root, removed := m.root.without(0, m.hashOf(key), key)
+	if removed {
+		m.root = root
+		m.size--
+	}
+	return m
+}
+
+// Range calls f for each entry until f returns false. The order is
+// unspecified but stable for a given map.
+func (m Map[K, V]) Range(f func(k K, v V) bool) {
+	if m.root != nil {
+		m.root.each(f)
+	}
+}
+
+// Keys returns the keys in Range order.
+func (m Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// ToMap returns the entries in a new built-in map.
+func (m Map[K, V]) ToMap() map[K]V {
+	out := make(map[K]V, m.size)
+	m.Range(func(k K, v V) bool {
+		out[k] = v
+		return true
+	})
+	return out
+}
@@ -0,0 +1,152 @@
+package persistent
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestMapWithWithout(t *testing.T) {
+	var m Map[string, int]
+	if _, ok := m.Get("a"); ok {
+		t.Fatal("zero Map should be empty")
+	}
+
+	m1 := m.With("a", 1).With("b", 2)
+	m2 := m1.With("a", 10).With("c", 3)
+	m3 := m2.Without("b").Without("missing")
+
+	check := func(name string, m Map[string, int], want map[string]int) {
+		t.Helper()
+		if got := m.ToMap(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+		if m.Len() != len(want) {
+			t.Errorf("%s.Len() = %d, want %d", name, m.Len(), len(want))
+		}
+	}
+	check("m", m, map[string]int{})
+	check("m1", m1, map[string]int{"a": 1, "b": 2})
+	check("m2", m2, map[string]int{"a": 10, "b": 2, "c": 3})
+	check("m3", m3, map[string]int{"a": 10, "c": 3})
+}
+
+func TestMapMany(t *testing.T) {
+	const n = 20000
+	m := NewMap[int, string]()
+	for i := 0; i < n; i++ {
+		m = m.With(i, strconv.Itoa(i))
+	}
+	if m.Len() != n {
+		t.Fatalf("Len() = %d, want %d", m.Len(), n)
+	}
+	for i := 0; i < n; i++ {
+		if v, ok := m.Get(i); !ok || v != strconv.Itoa(i) {
+			t.Fatalf("Get(%d) = %q, %v", i, v, ok)
+		}
+	}
+	half := m
+	for i := 0; i < n; i += 2 {
+		half = half.Without(i)
+	}
+	if half.Len() != n/2 {
+		t.Fatalf("Len() = %d after removing evens", half.Len())
+	}
+	for i := 0; i < n; i++ {
+		if half.Has(i) != (i%2 == 1) {
+			t.Fatalf("Has(%d) = %v", i, half.Has(i))
+		}
+		if !m.Has(i) {
+			t.Fatalf("original lost %d", i)
+		}
+	}
+}
+
+func TestMapCollisions(t *testing.T) {
+	// every key hashes the same, so all entries end up in one collision node
+	m := NewMapFunc[string, int](func(string) uint64 { return 42 })
+	for i := 0; i < 10; i++ {
+		m = m.With(strconv.Itoa(i), i)
+	}
+	m = m.With("3", 30)
+	if m.Len() != 10 {
+		t.Fatalf("Len() = %d, want 10", m.Len())
+	}
+	if v, _ := m.Get("3"); v != 30 {
+		t.Errorf("Get(3) = %d, want 30", v)
+	}
+	for i := 0; i < 10; i++ {
+		m = m.Without(strconv.Itoa(i))
+		if m.Has(strconv.Itoa(i)) || m.Len() != 9-i {
+			t.Fatalf("Without(%d) left Len() = %d", i, m.Len())
+		}
+	}
+}
+
+func TestMapFromMapRange(t *testing.T) {
+	src := map[string]int{"x": 1, "y": 2, "z": 3}
+	m := FromMap(src)
+	if got := m.ToMap(); !reflect.DeepEqual(got, src) {
+		t.Errorf("ToMap() = %v, want %v", got, src)
+	}
+	keys := m.Keys()
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"x", "y", "z"}) {
+		t.Errorf("Keys() = %v", keys)
+	}
+
+	count := 0
+	m.Range(func(string, int) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("Range did not stop, called %d times", count)
+	}
+}
+
+func TestHash(t *testing.T) {
+	if Hash(0.0) != Hash(math.Copysign(0, -1)) {
+		t.Error("+0 and -0 should hash the same")
+	}
+	type id int
+	type name string
+	if Hash(id(7)) != Hash(7) || Hash(name("a")) != Hash("a") {
+		t.Error("named types should hash like their kind")
+	}
+	x, y := new(int), new(int)
+	if Hash(x) != Hash(x) || Hash(x) == Hash(y) {
+		t.Error("pointers should hash by address")
+	}
+	ids := FromMap(map[id]bool{1: true})
+	if !ids.Has(1) || ids.Has(2) {
+		t.Error("named int keys not found")
+	}
+
+	type point struct{ X, Y float64 }
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Hash should panic for struct keys")
+			}
+		}()
+		Hash(point{1, 2})
+	}()
+	m := NewMapFunc[point, bool](func(p point) uint64 {
+		return Hash(p.X)*31 + Hash(p.Y)
+	}).With(point{0, 1}, true)
+	if !m.Has(point{math.Copysign(0, -1), 1}) || m.Has(point{1, 0}) {
+		t.Error("struct keys not found with NewMapFunc")
+	}
+}
+
+func BenchmarkMapWith(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var m Map[int, int]
+		for j := 0; j < 1000; j++ {
+			m = m.With(j, j)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package persistent
+
+const (
+	nodeBits = 5
+	width    = 1 << nodeBits
+	mask     = width - 1
+)
+
+type vnode[T any] struct {
+	children []*vnode[T]
+	values   []T
+}
+
+// Vector is an immutable list. Every update returns a new Vector sharing
+// most of its structure with the old one, so a Vector can be handed to other
+// goroutines without copying or locking.
+//
+// It is a 32-way trie with the last block kept in a tail, so Get and With are
+// O(log32 n) and Append is amortized O(1). The zero value is an empty vector.
+type Vector[T any] struct {
+	size  int
+	shift uint
+	root  *vnode[T]
+	tail  []T
+}
+
+// NewVector returns a vector holding values in order.
+func NewVector[T any](values ...T) Vector[T] {
+	return FromSlice(values)
+}
+
+// FromSlice returns a vector holding a copy of values.
+func FromSlice[T any](values []T) Vector[T] {
+	var v Vector[T]
+	for i := range values {
+		v = v.Append(values[i])
+	}
+	return v
+}
+
+// Len returns the number of values.
+func (v Vector[T]) Len() int {
+	return v.size
+}
+
+func (v Vector[T]) tailOffset() int {
+	if v.size < width {
+		return 0
+	}
+	return ((v.size - 1) >> nodeBits) << nodeBits
+}
+
+// leaf returns the block holding index i.
+func (v Vector[T]) leaf(i int) []T {
+	if i >= v.tailOffset() {
+		return v.tail
+	}
+	n := v.root
+	for level := v.shift; level > 0; level -= nodeBits {
+		n = n.children[(i>>level)&mask]
+	}
+	return n.values
+}
+
+// Get returns the value at index i, false if i is out of range.
+func (v Vector[T]) Get(i int) (T, bool) {
+	if i < 0 || i >= v.size {
+		var zero T
+		return zero, false
+	}
+	return v.leaf(i)[i&mask], true
+}
+
+// Append returns a vector with val added at the end.
+func (v Vector[T]) Append(val T) Vector[T] {
+	if v.size-v.tailOffset() < width {
+		tail := make([]T, len(v.tail)+1)
+		copy(tail, v.tail)
+		tail[len(v.tail)] = val
+		return Vector[T]{size: v.size + 1, shift: v.shift, root: v.root, tail: tail}
+	}
+
+	// the tail is full, move it into the trie
+	root, shift := v.root, v.shift
+	if root == nil {
+		root, shift = &vnode[T]{}, nodeBits
+	}
+	tailNode := &vnode[T]{values: v.tail}
+	if (v.size >> nodeBits) > (1 << shift) {
+		root = &vnode[T]{children: []*vnode[T]{root, newPath(shift, tailNode)}}
+		shift += nodeBits
+	} else {
+		root = pushTail(v.size, shift, root, tailNode)
+	}
+	return Vector[T]{size: v.size + 1, shift: shift, root: root, tail: []T{val}}
+}
+
+func newPath[T any](level uint, n *vnode[T]) *vnode[T] {
+	if level == 0 {
+		return n
+	}
+	return &vnode[T]{children: []*vnode[T]{newPath(level-nodeBits, n)}}
+}
+
+func pushTail[T any](size int, level uint, parent, tailNode *vnode[T]) *vnode[T] {
+	sub := ((size - 1) >> level) & mask
+	ret := &vnode[T]{children: make([]*vnode[T], len(parent.children), sub+1)}
+	copy(ret.children, parent.children)
+
+	var child *vnode[T]
+	switch {
+	case level == nodeBits:
+		child = tailNode
+	case sub < len(parent.children):
+		child = pushTail(size, level-nodeBits, parent.children[sub], tailNode)
+	default:
+		child = newPath(level-nodeBits, tailNode)
+	}
+	if sub < len(ret.children) {
+		ret.children[sub] = child
+	} else {
+		ret.children = append(ret.children, child)
+	}
+	return ret
+}
+
+// With returns a vector with the value at index i replaced by val.
+// It panics if i is out of range, like a slice index.
+func (v Vector[T]) With(i int, val T) Vector[T] {
+	if i < 0 || i >= v.size {
+		panic("persistent: index out of range")
+	}
+	if i >= v.tailOffset() {
+		tail := make([]T, len(v.tail))
+		copy(tail, v.tail)
+		tail[i&mask] = val
+		return Vector[T]{size: v.size, shift: v.shift, root: v.root, tail: tail}
+	}
+	return Vector[T]{size: v.size, shift: v.shift, root: assoc(v.shift, v.root, i, val), tail: v.tail}
+}
+
+func assoc[T any](level uint, n *vnode[T], i int, val T) *vnode[T] {
+	if level == 0 {
+		values := make([]T, len(n.values))
+		copy(values, n.values)
+		values[i&mask] = val
+		return &vnode[T]{values: values}
+	}
+	children := make([]*vnode[T], len(n.children))
+	copy(children, n.children)
+	sub := (i >> level) & mask
+	children[sub] = assoc(level-nodeBits, n.children[sub], i, val)
+	return &vnode[T]{children: children}
+}
+
+// Pop returns a vector without its last value, and that value.
+// It returns false if the vector is empty.
+func (v Vector[T]) Pop() (Vector[T], T, bool) {
+	var zero T
+	switch v.size {
+	case 0:
+		return v, zero, false
+	case 1:
+		return Vector[T]{}, v.tail[0], true
+	}
+
+	last := v.tail[len(v.tail)-1]
+	if len(v.tail) > 1 {
+		return Vector[T]{size: v.size - 1, shift: v.shift, root: v.root, tail: v.tail[: len(v.tail)-1 : len(v.tail)-1]}, last, true
+	}
+
+	// the tail becomes empty, take the last block of the trie as the new tail
+	tail := v.leaf(v.size - 2)
+	root, shift := popTail(v.size, v.shift, v.root), v.shift
+	if root == nil {
+		root = &vnode[T]{}
+	}
+	if shift > nodeBits && len(root.children) == 1 {
+		root, shift = root.children[0], shift-nodeBits
+	}
+	return Vector[T]{size: v.size - 1, shift: shift, root: root, tail: tail}, last, true
+}
+
+func popTail[T any](size int, level uint, n *vnode[T]) *vnode[T] {
+	sub := ((size - 2) >> level) & mask
+	if level > nodeBits {
+		child := popTail(size, level-nodeBits, n.children[sub])
+		if child == nil && sub == 0 {
+			return nil
+		}
+		ret := &vnode[T]{children: make([]*vnode[T], sub+1)}
+		copy(ret.children, n.children)
+		if child == nil {
+			ret.children = ret.children[:sub]
+		} else {
+			ret.children[sub] = child
+		}
+		return ret
+	}
+	if sub == 0 {
+		return nil
+	}
+	ret := &vnode[T]{children: make([]*vnode[T], sub)}
+	copy(ret.children, n.children)
+	return ret
+}
+
+// Range calls f for each index and value in order until f returns false.
+func (v Vector[T]) Range(f func(i int, val T) bool) {
+	for i := 0; i < v.size; i += width {
+		block := v.leaf(i)
+		for j := range block {
+			if !f(i+j, block[j]) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the values in a new slice.
+func (v Vector[T]) ToSlice() []T {
+	values := make([]T, 0, v.size)
+	for i := 0; i < v.size; i += width {
+		values = append(values, v.leaf(i)...)
+	}
+	return values
+}
@@ -0,0 +1,136 @@
+package persistent
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVectorAppendGet(t *testing.T) {
+	var v Vector[int]
+	versions := []Vector[int]{v}
+	for _, n := range []int{0, 1, 31, 32, 33, 1024, 1025, 32*32*32 + 33} {
+		v = Vector[int]{}
+		for i := 0; i < n; i++ {
+			v = v.Append(i)
+		}
+		if v.Len() != n {
+			t.Fatalf("Len() = %d, want %d", v.Len(), n)
+		}
+		for i := 0; i < n; i++ {
+			if got, ok := v.Get(i); !ok || got != i {
+				t.Fatalf("n=%d: Get(%d) = %d, %v", n, i, got, ok)
+			}
+		}
+		if _, ok := v.Get(n); ok {
+			t.Fatalf("n=%d: Get(%d) should be out of range", n, n)
+		}
+		versions = append(versions, v)
+	}
+	if versions[0].Len() != 0 {
+		t.Error("empty version changed")
+	}
+}
+
+func TestVectorSharing(t *testing.T) {
+	v1 := FromSlice([]string{"a", "b", "c"})
+	v2 := v1.Append("d")
+	v3 := v1.Append("x")
+	v4 := v2.With(0, "A")
+
+	want := map[*Vector[string]][]string{
+		&v1: {"a", "b", "c"},
+		&v2: {"a", "b", "c", "d"},
+		&v3: {"a", "b", "c", "x"},
+		&v4: {"A", "b", "c", "d"},
+	}
+	for v, w := range want {
+		if got := v.ToSlice(); !reflect.DeepEqual(got, w) {
+			t.Errorf("ToSlice() = %v, want %v", got, w)
+		}
+	}
+
+	big := NewVector[int]()
+	for i := 0; i < 2000; i++ {
+		big = big.Append(i)
+	}
+	changed := big.With(100, -1).With(1999, -2)
+	if got, _ := big.Get(100); got != 100 {
+		t.Errorf("original Get(100) = %d", got)
+	}
+	if got, _ := changed.Get(100); got != -1 {
+		t.Errorf("changed Get(100) = %d", got)
+	}
+	if got, _ := changed.Get(1999); got != -2 {
+		t.Errorf("changed Get(1999) = %d", got)
+	}
+}
+
+func TestVectorPop(t *testing.T) {
+	const n = 32*32 + 70
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+	}
+	v := FromSlice(values)
+	for i := n - 1; i >= 0; i-- {
+		var last int
+		var ok bool
+		v, last, ok = v.Pop()
+		if !ok || last != i {
+			t.Fatalf("Pop() = %d, %v, want %d", last, ok, i)
+		}
+		if v.Len() != i {
+			t.Fatalf("Len() = %d, want %d", v.Len(), i)
+		}
+		if i > 0 {
+			if got, _ := v.Get(i - 1); got != i-1 {
+				t.Fatalf("Get(%d) = %d after Pop", i-1, got)
+			}
+		}
+	}
+	if _, _, ok := v.Pop(); ok {
+		t.Error("Pop() on empty vector should fail")
+	}
+
+	// appending after pops must not write into blocks shared with the original
+	orig := FromSlice(values[:40])
+	popped, _, _ := orig.Pop()
+	popped = popped.Append(-1)
+	if got, _ := orig.Get(39); got != 39 {
+		t.Errorf("original Get(39) = %d after Append on popped version", got)
+	}
+	if got, _ := popped.Get(39); got != -1 {
+		t.Errorf("popped Get(39) = %d", got)
+	}
+}
+
+func TestVectorRange(t *testing.T) {
+	v := NewVector(1, 2, 3, 4)
+	var sum, count int
+	v.Range(func(i, val int) bool {
+		sum += val
+		count++
+		return i < 1
+	})
+	if count != 2 || sum != 3 {
+		t.Errorf("Range stopped after %d values, sum %d", count, sum)
+	}
+}
+
+func TestVectorWithPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("With out of range should panic")
+		}
+	}()
+	NewVector(1).With(1, 0)
+}
+
+func BenchmarkVectorAppend(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var v Vector[int]
+		for j := 0; j < 1000; j++ {
+			v = v.Append(j)
+		}
+	}
+}
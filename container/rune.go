@@ -0,0 +1,145 @@
+package container
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// cjkTable covers Han ideographs, kana, Hangul, bopomofo
+// and the CJK symbols and punctuation block.
+var cjkTable = []*unicode.RangeTable{
+	unicode.Han,
+	unicode.Hiragana,
+	unicode.Katakana,
+	unicode.Hangul,
+	unicode.Bopomofo,
+	{R16: []unicode.Range16{{Lo: 0x3000, Hi: 0x303f, Stride: 1}}},
+}
+
+// IsHan reports whether r is a Chinese character.
+func IsHan(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+// IsCJK reports whether r is a Chinese, Japanese or Korean character
+// or CJK punctuation.
+func IsCJK(r rune) bool {
+	return unicode.IsOneOf(cjkTable, r)
+}
+
+// emojiTable holds the blocks where emoji are encoded.
+var emojiTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x203c, Hi: 0x203c, Stride: 1}, // ‼
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1}, // ⁉
+		{Lo: 0x2190, Hi: 0x21ff, Stride: 1}, // arrows
+		{Lo: 0x2300, Hi: 0x23ff, Stride: 1}, // miscellaneous technical, ⌚ ⏰
+		{Lo: 0x2600, Hi: 0x27bf, Stride: 1}, // miscellaneous symbols, dingbats
+		{Lo: 0x2b00, Hi: 0x2bff, Stride: 1}, // arrows and stars, ⭐
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1}, // 〰
+		{Lo: 0x303d, Hi: 0x303d, Stride: 1}, // 〽
+		{Lo: 0x3297, Hi: 0x3299, Stride: 2}, // ㊗ ㊙
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f000, Hi: 0x1f2ff, Stride: 1}, // mahjong, cards, enclosed characters, flags
+		{Lo: 0x1f300, Hi: 0x1faff, Stride: 1}, // pictographs, emoticons, transport, supplemental
+	},
+}
+
+// IsEmoji reports whether r lies in one of the emoji blocks. Some runes in
+// these blocks are symbols usually shown as text, such as arrows.
+func IsEmoji(r rune) bool {
+	return unicode.Is(emojiTable, r)
+}
+
+// IsWhitespace reports whether r is a Unicode space, including the
+// ideographic space U+3000 that full-width input produces.
+func IsWhitespace(r rune) bool {
+	return unicode.IsSpace(r)
+}
+
+// softHyphen is a format character, but it is shown as a hyphen where a
+// line breaks at it, so terminals give it a column.
+const softHyphen = 0xad
+
+// IsZeroWidth reports whether r takes no room when displayed,
+// such as zero width spaces, joiners and combining marks.
+func IsZeroWidth(r rune) bool {
+	return r != softHyphen && unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf)
+}
+
+// IsLineBreak reports whether r ends a line.
+func IsLineBreak(r rune) bool {
+	switch r {
+	case '\n', '\r', '\v', '\f', 0x85, 0x2028, 0x2029:
+		return true
+	}
+	return false
+}
+
+// IsPunct reports whether r is punctuation, ASCII or not, such as ',' or '，'.
+func IsPunct(r rune) bool {
+	return unicode.IsPunct(r)
+}
+
+// IsFullWidth reports whether r is displayed two columns wide,
+// such as Chinese characters and full-width letters.
+func IsFullWidth(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianFullwidth, width.EastAsianWide:
+		return true
+	}
+	return false
+}
+
+// IsHalfWidth reports whether r is a narrow or half-width rune, such as ASCII or 'ｶ'.
+func IsHalfWidth(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianHalfwidth, width.EastAsianNarrow:
+		return true
+	}
+	return false
+}
+
+const (
+	fullWidthOffset  = 0xfee0
+	ideographicSpace = 0x3000
+)
+
+// ToHalfWidthRune converts a full-width ASCII variant, such as '１' or 'Ａ',
+// and the ideographic space to its ASCII form. Other runes are returned as is.
+func ToHalfWidthRune(r rune) rune {
+	switch {
+	case r == ideographicSpace:
+		return ' '
+	case r >= '！' && r <= '～':
+		return r - fullWidthOffset
+	}
+	return r
+}
+
+// ToFullWidthRune converts printable ASCII to its full-width form,
+// the inverse of ToHalfWidthRune.
+func ToFullWidthRune(r rune) rune {
+	switch {
+	case r == ' ':
+		return ideographicSpace
+	case r >= '!' && r <= '~':
+		return r + fullWidthOffset
+	}
+	return r
+}
+
+// ToHalfWidth converts the full-width ASCII variants in s to ASCII, so
+// "１２３ａｂｃ" pasted into a form becomes "123abc". Punctuation without an
+// ASCII form, such as '。' and '、', is kept.
+func ToHalfWidth(s string) string {
+	return strings.Map(ToHalfWidthRune, s)
+}
+
+// ToFullWidth converts the printable ASCII in s to full-width forms.
+func ToFullWidth(s string) string {
+	return strings.Map(ToFullWidthRune, s)
+}
@@ -0,0 +1,62 @@
+package container
+
+import "testing"
+
+func TestRuneClassification(t *testing.T) {
+	cases := []struct {
+		name string
+		f    func(rune) bool
+		yes  []rune
+		no   []rune
+	}{
+		{name: "IsHan", f: IsHan, yes: []rune{'中', '龍', '𠀀'}, no: []rune{'a', 'あ', '한', '，'}},
+		{name: "IsCJK", f: IsCJK, yes: []rune{'中', 'あ', 'カ', '한', 'ㄅ', '。', '　'}, no: []rune{'a', '1', 'é', '，'}},
+		{name: "IsEmoji", f: IsEmoji, yes: []rune{'😀', '👍', '❤', '⭐', '🇨', '🀄', '🤖'}, no: []rune{'a', '中', '#'}},
+		{name: "IsWhitespace", f: IsWhitespace, yes: []rune{' ', '\t', '\n', '　', 0x85, 0xa0}, no: []rune{'a', 0x200b}},
+		{name: "IsZeroWidth", f: IsZeroWidth, yes: []rune{0x200b, 0x200d, 0xfe0f, 0x301, 0xfeff}, no: []rune{'a', ' ', '中', 0xad, 0xfffd}},
+		{name: "IsLineBreak", f: IsLineBreak, yes: []rune{'\n', '\r', 0x2028}, no: []rune{' ', '\t'}},
+		{name: "IsPunct", f: IsPunct, yes: []rune{',', '!', '，', '。', '、', '「'}, no: []rune{'a', '+', ' '}},
+		{name: "IsFullWidth", f: IsFullWidth, yes: []rune{'中', 'Ａ', '１', '　', '，'}, no: []rune{'a', '1', 'ｶ', 'é'}},
+		{name: "IsHalfWidth", f: IsHalfWidth, yes: []rune{'a', '1', 'ｶ', '~'}, no: []rune{'中', 'Ａ', 'é'}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, r := range c.yes {
+				if !c.f(r) {
+					t.Errorf("%s(%q) = false, want true", c.name, r)
+				}
+			}
+			for _, r := range c.no {
+				if c.f(r) {
+					t.Errorf("%s(%q) = true, want false", c.name, r)
+				}
+			}
+		})
+	}
+}
+
+func TestWidthConversion(t *testing.T) {
+	cases := []struct {
+		full, half string
+	}{
+		{full: "１２３ａｂｃ", half: "123abc"},
+		{full: "ＡＢＣ　ｘｙｚ！", half: "ABC xyz!"},
+		{full: "～", half: "~"},
+		{full: "", half: ""},
+	}
+	for _, c := range cases {
+		if got := ToHalfWidth(c.full); got != c.half {
+			t.Errorf("ToHalfWidth(%q) = %q, want %q", c.full, got, c.half)
+		}
+		if got := ToFullWidth(c.half); got != c.full {
+			t.Errorf("ToFullWidth(%q) = %q, want %q", c.half, got, c.full)
+		}
+	}
+
+	if got := ToHalfWidth("电话：１３８，好。"); got != "电话:138,好。" {
+		t.Errorf("ToHalfWidth kept unexpected runes: %q", got)
+	}
+	if got := ToFullWidth("中文\n"); got != "中文\n" {
+		t.Errorf("ToFullWidth changed non ASCII: %q", got)
+	}
+}
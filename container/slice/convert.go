@@ -0,0 +1,198 @@
+package slice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ConvError records an element that could not be converted.
+type ConvError struct {
+	Index int    // position of the element in the input slice
+	Value string // the raw input value
+	Err   error  // the underlying strconv error
+}
+
+func (e *ConvError) Error() string {
+	return fmt.Sprintf("index %d: %v", e.Index, e.Err)
+}
+
+func (e *ConvError) Unwrap() error {
+	return e.Err
+}
+
+// ConvErrors is returned by the conversion functions when one or more
+// elements fail to convert. It is ordered by index.
+type ConvErrors []*ConvError
+
+func (es ConvErrors) Error() string {
+	switch len(es) {
+	case 0:
+		return "no conversion errors"
+	case 1:
+		return es[0].Error()
+	}
+
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(len(es)))
+	b.WriteString(" conversion errors: ")
+	for i, e := range es {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(e.Error())
+	}
+	return b.String()
+}
+
+type convMode uint8
+
+const (
+	convStrict convMode = iota
+	convSkip
+	convDefault
+)
+
+// ParseInts converts list to integers of type T in the given base.
+// base follows strconv.ParseInt, 0 means the base is implied by the prefix.
+//
+// Values are range checked against the bit size of T, so "300" fails for uint8
+// and the full uint64 range is supported. If any element fails, ParseInts
+// returns a nil slice and a ConvErrors describing every failed element.
+func ParseInts[T constraints.Integer, S ~string](list []S, base int) ([]T, error) {
+	return parseInts[T](list, base, convStrict, 0)
+}
+
+// ParseIntsSkip is like ParseInts but drops invalid elements.
+// The returned error still lists the dropped elements and their indexes.
+func ParseIntsSkip[T constraints.Integer, S ~string](list []S, base int) ([]T, error) {
+	return parseInts[T](list, base, convSkip, 0)
+}
+
+// ParseIntsOr is like ParseInts but replaces invalid elements with def,
+// so the result always has the same length as list.
+func ParseIntsOr[T constraints.Integer, S ~string](list []S, base int, def T) ([]T, error) {
+	return parseInts(list, base, convDefault, def)
+}
+
+func parseInts[T constraints.Integer, S ~string](list []S, base int, mode convMode, def T) ([]T, error) {
+	var zero T
+	bitSize := int(unsafe.Sizeof(zero)) * 8
+	signed := ^zero < 0
+
+	result := make([]T, 0, len(list))
+	var errs ConvErrors
+	for i := range list {
+		var (
+			v   T
+			err error
+		)
+		if signed {
+			var n int64
+			n, err = strconv.ParseInt(string(list[i]), base, bitSize)
+			v = T(n)
+		} else {
+			var n uint64
+			n, err = strconv.ParseUint(string(list[i]), base, bitSize)
+			v = T(n)
+		}
+
+		if err != nil {
+			errs = append(errs, &ConvError{Index: i, Value: string(list[i]), Err: err})
+			switch mode {
+			case convSkip:
+				continue
+			case convDefault:
+				v = def
+			}
+		}
+		result = append(result, v)
+	}
+
+	return convResult(result, errs, mode)
+}
+
+// ParseFloats converts list to floats of type T, range checked against
+// the bit size of T. If any element fails, ParseFloats returns a nil slice
+// and a ConvErrors describing every failed element.
+func ParseFloats[T constraints.Float, S ~string](list []S) ([]T, error) {
+	return parseFloats[T](list, convStrict, 0)
+}
+
+// ParseFloatsSkip is like ParseFloats but drops invalid elements.
+func ParseFloatsSkip[T constraints.Float, S ~string](list []S) ([]T, error) {
+	return parseFloats[T](list, convSkip, 0)
+}
+
+// ParseFloatsOr is like ParseFloats but replaces invalid elements with def.
+func ParseFloatsOr[T constraints.Float, S ~string](list []S, def T) ([]T, error) {
+	return parseFloats(list, convDefault, def)
+}
+
+func parseFloats[T constraints.Float, S ~string](list []S, mode convMode, def T) ([]T, error) {
+	var zero T
+	bitSize := int(unsafe.Sizeof(zero)) * 8
+
+	result := make([]T, 0, len(list))
+	var errs ConvErrors
+	for i := range list {
+		f, err := strconv.ParseFloat(string(list[i]), bitSize)
+		v := T(f)
+		if err != nil {
+			errs = append(errs, &ConvError{Index: i, Value: string(list[i]), Err: err})
+			switch mode {
+			case convSkip:
+				continue
+			case convDefault:
+				v = def
+			}
+		}
+		result = append(result, v)
+	}
+
+	return convResult(result, errs, mode)
+}
+
+func convResult[T any](result []T, errs ConvErrors, mode convMode) ([]T, error) {
+	if len(errs) == 0 {
+		return result, nil
+	}
+	if mode == convStrict {
+		return nil, errs
+	}
+	return result, errs
+}
+
+// FormatInts formats each integer in the given base (2 <= base <= 36).
+// Unsigned values are formatted without going through int64,
+// so values above math.MaxInt64 are preserved.
+func FormatInts[T constraints.Integer](list []T, base int) []string {
+	var zero T
+	signed := ^zero < 0
+
+	result := make([]string, len(list))
+	for i, v := range list {
+		if signed {
+			result[i] = strconv.FormatInt(int64(v), base)
+		} else {
+			result[i] = strconv.FormatUint(uint64(v), base)
+		}
+	}
+	return result
+}
+
+// FormatFloats formats each float with strconv.FormatFloat using the given
+// format and precision, and the bit size of T.
+func FormatFloats[T constraints.Float](list []T, format byte, prec int) []string {
+	var zero T
+	bitSize := int(unsafe.Sizeof(zero)) * 8
+
+	result := make([]string, len(list))
+	for i, v := range list {
+		result[i] = strconv.FormatFloat(float64(v), format, prec, bitSize)
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package slice
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type numStr string
+
+func TestParseInts(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []string
+		base    int
+		want    []int
+		wantIdx []int
+	}{
+		{name: "empty", input: []string{}, base: 10, want: []int{}},
+		{name: "valid", input: []string{"1", "-2", "30"}, base: 10, want: []int{1, -2, 30}},
+		{name: "hex", input: []string{"ff", "10"}, base: 16, want: []int{255, 16}},
+		{name: "prefix", input: []string{"0x1f", "0b11", "0o17"}, base: 0, want: []int{31, 3, 15}},
+		{name: "invalid", input: []string{"1", "a", "3", ""}, base: 10, want: nil, wantIdx: []int{1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInts[int](tt.input, tt.base)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseInts() = %v, want %v", got, tt.want)
+			}
+			if len(tt.wantIdx) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var errs ConvErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected ConvErrors, got %T", err)
+			}
+			idx := make([]int, len(errs))
+			for i := range errs {
+				idx[i] = errs[i].Index
+			}
+			assert.Equal(t, tt.wantIdx, idx)
+		})
+	}
+}
+
+func TestParseIntsBitSize(t *testing.T) {
+	_, err := ParseInts[uint8]([]string{"255", "256"}, 10)
+	var errs ConvErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", err)
+	}
+	assert.Equal(t, 1, errs[0].Index)
+	assert.Equal(t, "256", errs[0].Value)
+	assert.ErrorIs(t, err.(ConvErrors)[0], strconv.ErrRange)
+
+	_, err = ParseInts[int8]([]string{"-128", "-129"}, 10)
+	assert.Error(t, err)
+
+	_, err = ParseInts[uint]([]string{"-1"}, 10)
+	assert.Error(t, err)
+
+	got, err := ParseInts[uint64]([]string{"18446744073709551615"}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{math.MaxUint64}, got)
+}
+
+func TestParseIntsModes(t *testing.T) {
+	input := []numStr{"7", "x", "9"}
+
+	skipped, err := ParseIntsSkip[int32](input, 10)
+	assert.Error(t, err)
+	assert.Equal(t, []int32{7, 9}, skipped)
+
+	filled, err := ParseIntsOr(input, 10, int32(-1))
+	assert.Error(t, err)
+	assert.Equal(t, []int32{7, -1, 9}, filled)
+
+	filled, err = ParseIntsOr([]numStr{"1"}, 10, int32(-1))
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{1}, filled)
+}
+
+func TestParseFloats(t *testing.T) {
+	got, err := ParseFloats[float64]([]string{"1.5", "-2", "1e3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1.5, -2, 1000}, got)
+
+	got, err = ParseFloats[float64]([]string{"1.5", "abc"})
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	_, err = ParseFloats[float32]([]string{"1e39"})
+	assert.ErrorIs(t, err.(ConvErrors)[0], strconv.ErrRange)
+
+	skipped, err := ParseFloatsSkip[float32]([]string{"abc", "0.25"})
+	assert.Error(t, err)
+	assert.Equal(t, []float32{0.25}, skipped)
+
+	filled, err := ParseFloatsOr([]numStr{"abc", "0.25"}, 1.0)
+	assert.Error(t, err)
+	assert.Equal(t, []float64{1, 0.25}, filled)
+}
+
+func TestConvErrors_Error(t *testing.T) {
+	_, err := ParseInts[int]([]string{"a", "1", "b"}, 10)
+	assert.EqualError(t, err, `2 conversion errors: index 0: strconv.ParseInt: parsing "a": invalid syntax; `+
+		`index 2: strconv.ParseInt: parsing "b": invalid syntax`)
+}
+
+func TestFormatInts(t *testing.T) {
+	assert.Equal(t, []string{}, FormatInts([]int{}, 10))
+	assert.Equal(t, []string{"-1", "0", "10"}, FormatInts([]int8{-1, 0, 10}, 10))
+	assert.Equal(t, []string{"ff", "23"}, FormatInts([]int{255, 35}, 16))
+	assert.Equal(t, []string{"18446744073709551615"}, FormatInts([]uint64{math.MaxUint64}, 10))
+	assert.Equal(t, []string{"z"}, FormatInts([]uint{35}, 36))
+}
+
+func TestFormatFloats(t *testing.T) {
+	assert.Equal(t, []string{"0.1", "2.5"}, FormatFloats([]float32{0.1, 2.5}, 'f', -1))
+	assert.Equal(t, []string{"1.50", "-3.00"}, FormatFloats([]float64{1.5, -3}, 'f', 2))
+}
+
+func TestStringsToInts(t *testing.T) {
+	assert.Equal(t, []int{1, 0, 3}, StringsToInts[int]([]string{"1", "x", "3"}))
+	assert.Equal(t, []uint64{math.MaxUint64}, StringsToInts[uint64]([]string{"18446744073709551615"}))
+	assert.Equal(t, []string{"18446744073709551615"}, IntsToStrings([]uint64{math.MaxUint64}))
+}
+
+func BenchmarkParseInts(b *testing.B) {
+	list := IntsToStrings([]int{1, 22, 333, 4444, 55555, 666666, 7777777})
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseInts[int](list, 10)
+	}
+}
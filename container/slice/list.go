@@ -1,7 +1,6 @@
 package slice
 
 import (
-	"strconv"
 	"strings"
 
 	"golang.org/x/exp/constraints"
@@ -42,6 +41,8 @@ func Distinct[T comparable](list []T) []T {
 	return result
 }
 
+// Merge concatenates origin and target into a new list.
+// Use MergeSorted to merge sorted lists.
 func Merge[T constraints.Ordered](origin, target []T) []T {
 	merged := make([]T, len(origin)+len(target))
 	copy(merged, origin)
@@ -84,20 +85,15 @@ func Split[T any](list []T, size int) [][]T {
 	return result
 }
 
+// IntsToStrings formats l in base 10, see FormatInts.
 func IntsToStrings[K constraints.Integer](l []K) []string {
-	result := make([]string, len(l))
-	for i, v := range l {
-		result[i] = strconv.FormatInt(int64(v), 10)
-	}
-	return result
+	return FormatInts(l, 10)
 }
 
+// StringsToInts parses list in base 10, unparsable or out of range values become 0.
+// Use ParseInts to get the per-element errors.
 func StringsToInts[T constraints.Integer](list []string) []T {
-	result := make([]T, 0, len(list))
-	for _, v := range list {
-		n, _ := strconv.ParseInt(v, 10, 64)
-		result = append(result, T(n))
-	}
+	result, _ := ParseIntsOr[T](list, 10, 0)
 	return result
 }
 
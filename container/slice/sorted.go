@@ -0,0 +1,244 @@
+package slice
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// MergeSorted merges sorted lists into a new sorted list, duplicates are kept.
+// Two lists are merged directly, more lists are merged with a min-heap,
+// so the cost is O(n log k) for n elements in k lists.
+//
+// [1, 4], [2, 3], [0, 5] => [0, 1, 2, 3, 4, 5]
+func MergeSorted[T constraints.Ordered](lists ...[]T) []T {
+	total := 0
+	for i := range lists {
+		total += len(lists[i])
+	}
+	result := make([]T, 0, total)
+
+	switch len(lists) {
+	case 0:
+		return result
+	case 1:
+		return append(result, lists[0]...)
+	case 2:
+		return mergeTwo(result, lists[0], lists[1])
+	}
+
+	h := make(mergeHeap[T], 0, len(lists))
+	for i := range lists {
+		if len(lists[i]) > 0 {
+			h = append(h, mergeCursor[T]{list: lists[i]})
+		}
+	}
+	heap.Init(&h)
+	for len(h) > 0 {
+		c := &h[0]
+		result = append(result, c.list[c.pos])
+		c.pos++
+		if c.pos == len(c.list) {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return result
+}
+
+func mergeTwo[T constraints.Ordered](dst, a, b []T) []T {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if b[j] < a[i] {
+			dst = append(dst, b[j])
+			j++
+		} else {
+			dst = append(dst, a[i])
+			i++
+		}
+	}
+	dst = append(dst, a[i:]...)
+	return append(dst, b[j:]...)
+}
+
+type mergeCursor[T constraints.Ordered] struct {
+	list []T
+	pos  int
+}
+
+type mergeHeap[T constraints.Ordered] []mergeCursor[T]
+
+func (h mergeHeap[T]) Len() int           { return len(h) }
+func (h mergeHeap[T]) Less(i, j int) bool { return h[i].list[h[i].pos] < h[j].list[h[j].pos] }
+func (h mergeHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap[T]) Push(x any)        { *h = append(*h, x.(mergeCursor[T])) }
+func (h *mergeHeap[T]) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// InsertSorted inserts v into the sorted list, after any equal elements,
+// and returns the updated list.
+func InsertSorted[T constraints.Ordered](list []T, v T) []T {
+	i := sort.Search(len(list), func(i int) bool { return list[i] > v })
+	var zero T
+	list = append(list, zero)
+	copy(list[i+1:], list[i:])
+	list[i] = v
+	return list
+}
+
+// RemoveSorted removes the first element equal to v from the sorted list.
+// It returns the updated list and whether v was found.
+func RemoveSorted[T constraints.Ordered](list []T, v T) ([]T, bool) {
+	i := sort.Search(len(list), func(i int) bool { return list[i] >= v })
+	if i == len(list) || list[i] != v {
+		return list, false
+	}
+	return append(list[:i], list[i+1:]...), true
+}
+
+// DedupSorted removes consecutive duplicates from the sorted list in place
+// and returns the shortened list.
+//
+// [1, 1, 2, 3, 3] => [1, 2, 3]
+func DedupSorted[T comparable](list []T) []T {
+	if len(list) <= 1 {
+		return list
+	}
+
+	n := 1
+	for i := 1; i < len(list); i++ {
+		if list[i] != list[n-1] {
+			list[n] = list[i]
+			n++
+		}
+	}
+	return list[:n]
+}
+
+// IntersectSorted returns the distinct elements present in both sorted lists.
+//
+// [1, 2, 2, 3], [2, 3, 4] => [2, 3]
+func IntersectSorted[T constraints.Ordered](a, b []T) []T {
+	result := make([]T, 0)
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case b[j] < a[i]:
+			j++
+		default:
+			if len(result) == 0 || result[len(result)-1] != a[i] {
+				result = append(result, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// UnionSorted returns the distinct elements present in either sorted list.
+//
+// [1, 2, 2], [2, 3] => [1, 2, 3]
+func UnionSorted[T constraints.Ordered](a, b []T) []T {
+	return DedupSorted(mergeTwo(make([]T, 0, len(a)+len(b)), a, b))
+}
+
+// DifferenceSorted returns the distinct elements of sorted list a that are not in b.
+//
+// [1, 2, 3, 3, 4], [2, 4] => [1, 3]
+func DifferenceSorted[T constraints.Ordered](a, b []T) []T {
+	result := make([]T, 0)
+	j := 0
+	for i := range a {
+		for j < len(b) && b[j] < a[i] {
+			j++
+		}
+		if j < len(b) && b[j] == a[i] {
+			continue
+		}
+		if len(result) == 0 || result[len(result)-1] != a[i] {
+			result = append(result, a[i])
+		}
+	}
+	return result
+}
+
+// FormatRanges compresses sorted integers into a range list,
+// the inverse of ParseRanges.
+//
+// [1, 2, 3, 5, 7, 8] => "1-3,5,7-8"
+func FormatRanges[K constraints.Integer](nums []K) string {
+	var b strings.Builder
+	for i, r := range MergeSortedAdjacent(nums) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(FormatInts(r[:1], 10)[0])
+		if len(r) > 1 {
+			b.WriteByte('-')
+			b.WriteString(FormatInts(r[1:], 10)[0])
+		}
+	}
+	return b.String()
+}
+
+// ParseRanges expands a range list produced by FormatRanges back into integers.
+// Spaces around items are ignored and a negative bound is written with its sign,
+// such as "-3--1". limit caps the number of expanded values, so untrusted input
+// such as "1-1000000000" cannot allocate unbounded memory; limit <= 0 means no limit.
+//
+// "1-3,5" => [1, 2, 3, 5]
+func ParseRanges[K constraints.Integer](s string, limit int) ([]K, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return []K{}, nil
+	}
+
+	result := make([]K, 0)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		nums, err := ParseInts[K](splitRange(item), 10)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range %q: %w", item, err)
+		}
+		lo, hi := nums[0], nums[len(nums)-1]
+		if lo > hi {
+			return nil, fmt.Errorf("invalid range %q: start is greater than end", item)
+		}
+
+		for v := lo; ; v++ {
+			if limit > 0 && len(result) >= limit {
+				return nil, fmt.Errorf("too many values, limit is %d", limit)
+			}
+			result = append(result, v)
+			if v == hi {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// splitRange splits "lo-hi" into its bounds, the sign of a negative lower bound
+// is not taken as the separator.
+func splitRange(item string) []string {
+	if len(item) < 2 {
+		return []string{item}
+	}
+	sep := strings.IndexByte(item[1:], '-')
+	if sep < 0 {
+		return []string{item}
+	}
+	sep++
+	return []string{strings.TrimSpace(item[:sep]), strings.TrimSpace(item[sep+1:])}
+}
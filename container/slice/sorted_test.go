@@ -0,0 +1,133 @@
+package slice
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSorted(t *testing.T) {
+	tests := []struct {
+		name  string
+		lists [][]int
+		want  []int
+	}{
+		{name: "no list", lists: nil, want: []int{}},
+		{name: "single list", lists: [][]int{{1, 2, 3}}, want: []int{1, 2, 3}},
+		{name: "two lists", lists: [][]int{{1, 4, 7}, {2, 4, 8, 9}}, want: []int{1, 2, 4, 4, 7, 8, 9}},
+		{name: "empty lists", lists: [][]int{{}, {1}, {}}, want: []int{1}},
+		{name: "k lists", lists: [][]int{{1, 4}, {2, 3}, {0, 5}, {3}}, want: []int{0, 1, 2, 3, 3, 4, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeSorted(tt.lists...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeSorted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertRemoveSorted(t *testing.T) {
+	var list []int
+	for _, v := range []int{5, 1, 3, 3, 9, 0} {
+		list = InsertSorted(list, v)
+	}
+	assert.Equal(t, []int{0, 1, 3, 3, 5, 9}, list)
+
+	list, ok := RemoveSorted(list, 3)
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 1, 3, 5, 9}, list)
+
+	list, ok = RemoveSorted(list, 4)
+	assert.False(t, ok)
+	assert.Equal(t, []int{0, 1, 3, 5, 9}, list)
+
+	list, ok = RemoveSorted(list, 9)
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 1, 3, 5}, list)
+}
+
+func TestDedupSorted(t *testing.T) {
+	assert.Equal(t, []int{}, DedupSorted([]int{}))
+	assert.Equal(t, []int{1, 2, 3}, DedupSorted([]int{1, 1, 2, 3, 3, 3}))
+	assert.Equal(t, []string{"a", "b"}, DedupSorted([]string{"a", "b", "b"}))
+}
+
+func TestSortedSetOperations(t *testing.T) {
+	a := []int{1, 2, 2, 3, 5, 7}
+	b := []int{2, 3, 4, 7, 7, 8}
+
+	assert.Equal(t, []int{2, 3, 7}, IntersectSorted(a, b))
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 7, 8}, UnionSorted(a, b))
+	assert.Equal(t, []int{1, 5}, DifferenceSorted(a, b))
+	assert.Equal(t, []int{4, 8}, DifferenceSorted(b, a))
+
+	assert.Equal(t, []int{}, IntersectSorted(a, nil))
+	assert.Equal(t, []int{1, 2, 3, 5, 7}, UnionSorted(a, nil))
+	assert.Equal(t, []int{1, 2, 3, 5, 7}, DifferenceSorted(a, nil))
+}
+
+func TestFormatRanges(t *testing.T) {
+	assert.Equal(t, "", FormatRanges([]int{}))
+	assert.Equal(t, "1-3,5", FormatRanges([]int{1, 2, 3, 5}))
+	assert.Equal(t, "1-3,5,7-8", FormatRanges([]int{1, 2, 2, 3, 5, 7, 8}))
+	assert.Equal(t, "-3--1,4", FormatRanges([]int{-3, -2, -1, 4}))
+}
+
+func TestParseRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		limit   int
+		want    []int
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: []int{}},
+		{name: "ranges", input: "1-3,5", want: []int{1, 2, 3, 5}},
+		{name: "spaces", input: " 1 - 2 , 4 ", want: []int{1, 2, 4}},
+		{name: "negative", input: "-3--1,0", want: []int{-3, -2, -1, 0}},
+		{name: "negative single", input: "-3", want: []int{-3}},
+		{name: "invalid number", input: "1-a", wantErr: true},
+		{name: "empty item", input: "1,,2", wantErr: true},
+		{name: "reversed", input: "5-1", wantErr: true},
+		{name: "limit", input: "1-1000000000", limit: 100, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRanges[int](tt.input, tt.limit)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := ParseRanges[uint8]("254-255", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint8{254, math.MaxUint8}, got)
+}
+
+func TestRangesRoundTrip(t *testing.T) {
+	ids := []int64{1, 2, 3, 10, 11, 20, 100, 101, 102}
+	got, err := ParseRanges[int64](FormatRanges(ids), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, ids, got)
+}
+
+func BenchmarkMergeSorted(b *testing.B) {
+	lists := make([][]int, 8)
+	for i := range lists {
+		for j := 0; j < 1000; j++ {
+			lists[i] = append(lists[i], j*len(lists)+i)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = MergeSorted(lists...)
+	}
+}
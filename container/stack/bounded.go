@@ -0,0 +1,118 @@
+package stack
+
+import "errors"
+
+// ErrFull is returned by TryPush when a bounded stack rejects a value.
+var ErrFull = errors.New("stack is full")
+
+// OverflowPolicy decides what a bounded stack does when pushing onto a full stack.
+type OverflowPolicy uint8
+
+const (
+	// OverflowReject discards the pushed value.
+	OverflowReject OverflowPolicy = iota
+	// OverflowDropBottom evicts the bottom value to make room, as an undo history does.
+	OverflowDropBottom
+)
+
+// boundedStack is a stack with a fixed capacity, stored in a ring buffer
+// so dropping the bottom value is O(1).
+type boundedStack[T any] struct {
+	buf    []T
+	bottom int
+	n      int
+	policy OverflowPolicy
+}
+
+// NewBounded returns a stack holding at most cap values, a cap less than 1 is treated as 1.
+func NewBounded[T any](cap int, policy OverflowPolicy) *boundedStack[T] {
+	if cap < 1 {
+		cap = 1
+	}
+	return &boundedStack[T]{buf: make([]T, cap), policy: policy}
+}
+
+func (bs *boundedStack[T]) index(i int) int {
+	return (bs.bottom + i) % len(bs.buf)
+}
+
+// Push pushes val, applying the overflow policy if the stack is full.
+func (bs *boundedStack[T]) Push(val T) {
+	_ = bs.TryPush(val)
+}
+
+// TryPush is like Push but returns ErrFull if val was rejected by OverflowReject.
+func (bs *boundedStack[T]) TryPush(val T) error {
+	if bs.IsFull() {
+		if bs.policy == OverflowReject {
+			return ErrFull
+		}
+		var zero T
+		bs.buf[bs.bottom] = zero
+		bs.bottom = bs.index(1)
+		bs.n--
+	}
+	bs.buf[bs.index(bs.n)] = val
+	bs.n++
+	return nil
+}
+
+func (bs *boundedStack[T]) Pop() (T, bool) {
+	var v T
+	if bs.IsEmpty() {
+		return v, false
+	}
+	top := bs.index(bs.n - 1)
+	v = bs.buf[top]
+	var zero T
+	bs.buf[top] = zero
+	bs.n--
+	return v, true
+}
+
+func (bs *boundedStack[T]) Peek() (T, bool) {
+	var v T
+	if bs.IsEmpty() {
+		return v, false
+	}
+	return bs.buf[bs.index(bs.n-1)], true
+}
+
+func (bs *boundedStack[T]) Len() int {
+	return bs.n
+}
+
+func (bs *boundedStack[T]) Cap() int {
+	return len(bs.buf)
+}
+
+func (bs *boundedStack[T]) IsEmpty() bool {
+	return bs.n == 0
+}
+
+func (bs *boundedStack[T]) IsFull() bool {
+	return bs.n == len(bs.buf)
+}
+
+func (bs *boundedStack[T]) ToSlice() []T {
+	values := make([]T, bs.n)
+	for i := range values {
+		values[i] = bs.buf[bs.index(i)]
+	}
+	return values
+}
+
+func (bs *boundedStack[T]) Range(f func(val T) bool) {
+	for i := bs.n - 1; i >= 0; i-- {
+		if !f(bs.buf[bs.index(i)]) {
+			return
+		}
+	}
+}
+
+// Clone returns a copy of the stack with the same capacity and policy.
+func (bs *boundedStack[T]) Clone() *boundedStack[T] {
+	buf := make([]T, len(bs.buf))
+	copy(buf, bs.buf)
+	return &boundedStack[T]{buf: buf, bottom: bs.bottom, n: bs.n, policy: bs.policy}
+}
@@ -0,0 +1,63 @@
+package stack
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBoundedStack_reject(t *testing.T) {
+	s := NewBounded[int](2, OverflowReject)
+	if err := s.TryPush(1); err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+	s.Push(2)
+	if err := s.TryPush(3); !errors.Is(err, ErrFull) {
+		t.Errorf("Expected ErrFull, but got %v", err)
+	}
+	if got := s.ToSlice(); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("Expected [1 2], but got %v", got)
+	}
+	if !s.IsFull() || s.Cap() != 2 {
+		t.Errorf("Expected full stack of cap 2, got len %d cap %d", s.Len(), s.Cap())
+	}
+}
+
+func TestBoundedStack_dropBottom(t *testing.T) {
+	s := NewBounded[int](3, OverflowDropBottom)
+	for i := 1; i <= 5; i++ {
+		if err := s.TryPush(i); err != nil {
+			t.Errorf("Expected no error, but got %v", err)
+		}
+	}
+	if got := s.ToSlice(); !reflect.DeepEqual(got, []int{3, 4, 5}) {
+		t.Errorf("Expected [3 4 5], but got %v", got)
+	}
+
+	if v, _ := s.Pop(); v != 5 {
+		t.Errorf("Expected 5, but got %d", v)
+	}
+	s.Push(6)
+	s.Push(7)
+	if got := s.ToSlice(); !reflect.DeepEqual(got, []int{4, 6, 7}) {
+		t.Errorf("Expected [4 6 7], but got %v", got)
+	}
+
+	c := s.Clone()
+	c.Push(8)
+	if got := s.ToSlice(); !reflect.DeepEqual(got, []int{4, 6, 7}) {
+		t.Errorf("Expected clone to be independent, but got %v", got)
+	}
+	if got := c.ToSlice(); !reflect.DeepEqual(got, []int{6, 7, 8}) {
+		t.Errorf("Expected [6 7 8], but got %v", got)
+	}
+}
+
+func TestBoundedStack_minCap(t *testing.T) {
+	s := NewBounded[string](0, OverflowDropBottom)
+	s.Push("a")
+	s.Push("b")
+	if v, ok := s.Peek(); !ok || v != "b" || s.Len() != 1 {
+		t.Errorf("Expected single value b, but got %v", s.ToSlice())
+	}
+}
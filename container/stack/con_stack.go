@@ -0,0 +1,84 @@
+package stack
+
+import "sync"
+
+// conStack guards another stack with a read-write lock.
+type conStack[T any] struct {
+	lock sync.RWMutex
+	s    Stack[T]
+}
+
+// NewConStack returns a stack that is safe for concurrent use.
+// All access must go through the returned stack, s must not be used directly afterwards.
+// If s is nil a slice stack is used.
+func NewConStack[T any](s Stack[T]) *conStack[T] {
+	if s == nil {
+		s = NewSliceStack[T](0)
+	}
+	return &conStack[T]{
+		lock: sync.RWMutex{},
+		s:    s,
+	}
+}
+
+func (cs *conStack[T]) Push(val T) {
+	cs.lock.Lock()
+	cs.s.Push(val)
+	cs.lock.Unlock()
+}
+
+func (cs *conStack[T]) Pop() (T, bool) {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+	return cs.s.Pop()
+}
+
+func (cs *conStack[T]) Peek() (T, bool) {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+	return cs.s.Peek()
+}
+
+func (cs *conStack[T]) Len() int {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+	return cs.s.Len()
+}
+
+func (cs *conStack[T]) IsEmpty() bool {
+	return cs.Len() == 0
+}
+
+func (cs *conStack[T]) ToSlice() []T {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+	return cs.s.ToSlice()
+}
+
+// Range iterates a snapshot of the stack, so f may call the stack itself.
+func (cs *conStack[T]) Range(f func(val T) bool) {
+	values := cs.ToSlice()
+	for i := len(values) - 1; i >= 0; i-- {
+		if !f(values[i]) {
+			return
+		}
+	}
+}
+
+// PopIf pops the top value only if f returns true for it, atomically.
+func (cs *conStack[T]) PopIf(f func(top T) bool) (T, bool) {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	top, ok := cs.s.Peek()
+	if !ok || !f(top) {
+		var zero T
+		return zero, false
+	}
+	return cs.s.Pop()
+}
+
+// Clone returns a concurrent stack holding a copy of the values, backed by a slice stack.
+func (cs *conStack[T]) Clone() *conStack[T] {
+	return NewConStack[T](&stackSlice[T]{l: cs.ToSlice()})
+}
@@ -0,0 +1,72 @@
+package stack
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConStack_concurrent(t *testing.T) {
+	s := NewConStack[int](NewSliceStack[int](0))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				s.Push(n)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if s.Len() != 8000 {
+		t.Fatalf("Expected 8000, but got %d", s.Len())
+	}
+
+	popped := make(chan int, 8000)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, ok := s.Pop()
+				if !ok {
+					return
+				}
+				popped <- v
+			}
+		}()
+	}
+	wg.Wait()
+	close(popped)
+	if len(popped) != 8000 || !s.IsEmpty() {
+		t.Errorf("Expected 8000 pops, but got %d", len(popped))
+	}
+}
+
+func TestConStack_PopIf(t *testing.T) {
+	s := NewConStack[int](nil)
+	s.Push(1)
+	s.Push(2)
+
+	if _, ok := s.PopIf(func(top int) bool { return top > 5 }); ok {
+		t.Error("Expected PopIf to keep the top value")
+	}
+	if v, ok := s.PopIf(func(top int) bool { return top == 2 }); !ok || v != 2 {
+		t.Errorf("Expected to pop 2, but got %d %v", v, ok)
+	}
+
+	c := s.Clone()
+	c.Push(3)
+	if s.Len() != 1 || c.Len() != 2 {
+		t.Errorf("Expected clone to be independent, got %v and %v", s.ToSlice(), c.ToSlice())
+	}
+
+	s.Range(func(val int) bool {
+		s.Push(val)
+		return true
+	})
+	if s.Len() != 2 {
+		t.Errorf("Expected 2, but got %d", s.Len())
+	}
+}
@@ -0,0 +1,96 @@
+package stack
+
+import "golang.org/x/exp/constraints"
+
+type minMaxEntry[T constraints.Ordered] struct {
+	val, min, max T
+}
+
+// MinMaxStack is a stack that reports its minimum and maximum value in O(1).
+// Each entry records the min and max of the values below it.
+type MinMaxStack[T constraints.Ordered] struct {
+	l []minMaxEntry[T]
+}
+
+func NewMinMax[T constraints.Ordered](cap uint) *MinMaxStack[T] {
+	return &MinMaxStack[T]{l: make([]minMaxEntry[T], 0, cap)}
+}
+
+func (ms *MinMaxStack[T]) Push(val T) {
+	e := minMaxEntry[T]{val: val, min: val, max: val}
+	if n := len(ms.l); n > 0 {
+		if below := ms.l[n-1].min; below < val {
+			e.min = below
+		}
+		if below := ms.l[n-1].max; below > val {
+			e.max = below
+		}
+	}
+	ms.l = append(ms.l, e)
+}
+
+func (ms *MinMaxStack[T]) Pop() (T, bool) {
+	if ms.IsEmpty() {
+		var v T
+		return v, false
+	}
+	v := ms.l[len(ms.l)-1].val
+	ms.l = ms.l[:len(ms.l)-1]
+	return v, true
+}
+
+func (ms *MinMaxStack[T]) Peek() (T, bool) {
+	if ms.IsEmpty() {
+		var v T
+		return v, false
+	}
+	return ms.l[len(ms.l)-1].val, true
+}
+
+// Min returns the smallest value in the stack, false if the stack is empty.
+func (ms *MinMaxStack[T]) Min() (T, bool) {
+	if ms.IsEmpty() {
+		var v T
+		return v, false
+	}
+	return ms.l[len(ms.l)-1].min, true
+}
+
+// Max returns the largest value in the stack, false if the stack is empty.
+func (ms *MinMaxStack[T]) Max() (T, bool) {
+	if ms.IsEmpty() {
+		var v T
+		return v, false
+	}
+	return ms.l[len(ms.l)-1].max, true
+}
+
+func (ms *MinMaxStack[T]) Len() int {
+	return len(ms.l)
+}
+
+func (ms *MinMaxStack[T]) IsEmpty() bool {
+	return len(ms.l) == 0
+}
+
+func (ms *MinMaxStack[T]) ToSlice() []T {
+	values := make([]T, len(ms.l))
+	for i := range ms.l {
+		values[i] = ms.l[i].val
+	}
+	return values
+}
+
+func (ms *MinMaxStack[T]) Range(f func(val T) bool) {
+	for i := len(ms.l) - 1; i >= 0; i-- {
+		if !f(ms.l[i].val) {
+			return
+		}
+	}
+}
+
+func (ms *MinMaxStack[T]) Clone() *MinMaxStack[T] {
+	l := make([]minMaxEntry[T], len(ms.l))
+	copy(l, ms.l)
+	return &MinMaxStack[T]{l: l}
+}
@@ -0,0 +1,49 @@
+package stack
+
+import "testing"
+
+func TestMinMaxStack(t *testing.T) {
+	s := NewMinMax[int](0)
+	if _, ok := s.Min(); ok {
+		t.Error("Expected Min on empty stack to fail")
+	}
+	if _, ok := s.Max(); ok {
+		t.Error("Expected Max on empty stack to fail")
+	}
+
+	steps := []struct {
+		push     int
+		min, max int
+	}{
+		{push: 5, min: 5, max: 5},
+		{push: 3, min: 3, max: 5},
+		{push: 7, min: 3, max: 7},
+		{push: 3, min: 3, max: 7},
+		{push: 1, min: 1, max: 7},
+	}
+	for _, step := range steps {
+		s.Push(step.push)
+		if v, _ := s.Min(); v != step.min {
+			t.Errorf("after push %d: expected min %d, but got %d", step.push, step.min, v)
+		}
+		if v, _ := s.Max(); v != step.max {
+			t.Errorf("after push %d: expected max %d, but got %d", step.push, step.max, v)
+		}
+	}
+
+	for i := len(steps) - 1; i > 0; i-- {
+		s.Pop()
+		if v, _ := s.Min(); v != steps[i-1].min {
+			t.Errorf("after pop: expected min %d, but got %d", steps[i-1].min, v)
+		}
+		if v, _ := s.Max(); v != steps[i-1].max {
+			t.Errorf("after pop: expected max %d, but got %d", steps[i-1].max, v)
+		}
+	}
+
+	c := s.Clone()
+	c.Push(10)
+	if v, _ := s.Max(); v != 5 {
+		t.Errorf("Expected clone to be independent, but got max %d", v)
+	}
+}
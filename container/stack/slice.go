@@ -18,6 +18,9 @@ func (ss *stackSlice[K]) Pop() (K, bool) {
 		return v, false
 	}
 	v = ss.l[len(ss.l)-1]
+	// clear the slot so the popped value can be collected
+	var zero K
+	ss.l[len(ss.l)-1] = zero
 	ss.l = ss.l[:len(ss.l)-1]
 	return v, true
 }
@@ -31,6 +34,29 @@ func (ss *stackSlice[K]) Peek() (K, bool) {
 	return v, true
 }
 
+func (ss *stackSlice[K]) Len() int {
+	return len(ss.l)
+}
+
 func (ss *stackSlice[K]) IsEmpty() bool {
 	return len(ss.l) == 0
 }
+
+func (ss *stackSlice[K]) ToSlice() []K {
+	values := make([]K, len(ss.l))
+	copy(values, ss.l)
+	return values
+}
+
+func (ss *stackSlice[K]) Range(f func(val K) bool) {
+	for i := len(ss.l) - 1; i >= 0; i-- {
+		if !f(ss.l[i]) {
+			return
+		}
+	}
+}
+
+// Clone returns a copy of the stack, the values themselves are copied shallowly.
+func (ss *stackSlice[K]) Clone() *stackSlice[K] {
+	return &stackSlice[K]{l: ss.ToSlice()}
+}
@@ -2,6 +2,29 @@ package stack
 
 import "container/list"
 
+// Stack is a last-in-first-out collection, implemented by every stack in this package.
+type Stack[T any] interface {
+	Push(val T)
+	// Pop removes and returns the top value, false if the stack is empty.
+	Pop() (T, bool)
+	// Peek returns the top value without removing it, false if the stack is empty.
+	Peek() (T, bool)
+	Len() int
+	IsEmpty() bool
+	// ToSlice returns the values from bottom to top in a new slice.
+	ToSlice() []T
+	// Range calls f for each value from top to bottom until f returns false.
+	Range(f func(val T) bool)
+}
+
+var (
+	_ Stack[int] = (*stack[int])(nil)
+	_ Stack[int] = (*stackSlice[int])(nil)
+	_ Stack[int] = (*boundedStack[int])(nil)
+	_ Stack[int] = (*conStack[int])(nil)
+	_ Stack[int] = (*MinMaxStack[int])(nil)
+)
+
 type stack[V any] struct {
 	l *list.List
 }
@@ -37,3 +60,26 @@ func (st *stack[V]) Len() int {
 func (st *stack[V]) IsEmpty() bool {
 	return st.Len() == 0
 }
+
+func (st *stack[V]) ToSlice() []V {
+	values := make([]V, 0, st.l.Len())
+	for e := st.l.Front(); e != nil; e = e.Next() {
+		values = append(values, e.Value.(V))
+	}
+	return values
+}
+
+func (st *stack[V]) Range(f func(val V) bool) {
+	for e := st.l.Back(); e != nil; e = e.Prev() {
+		if !f(e.Value.(V)) {
+			return
+		}
+	}
+}
+
+// Clone returns a copy of the stack, the values themselves are copied shallowly.
+func (st *stack[V]) Clone() *stack[V] {
+	c := New[V]()
+	c.l.PushBackList(st.l)
+	return c
+}
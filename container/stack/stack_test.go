@@ -1,6 +1,7 @@
 package stack
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -87,3 +88,75 @@ func TestStack_any(t *testing.T) {
 		t.Error("Expected true, but got", s.IsEmpty())
 	}
 }
+
+func TestStack_implementations(t *testing.T) {
+	stacks := map[string]Stack[int]{
+		"list":     New[int](),
+		"slice":    NewSliceStack[int](2),
+		"bounded":  NewBounded[int](10, OverflowReject),
+		"con":      NewConStack[int](nil),
+		"min max":  NewMinMax[int](0),
+		"con list": NewConStack[int](New[int]()),
+	}
+	for name, s := range stacks {
+		t.Run(name, func(t *testing.T) {
+			if _, ok := s.Pop(); ok {
+				t.Error("Expected Pop on empty stack to fail")
+			}
+			if _, ok := s.Peek(); ok {
+				t.Error("Expected Peek on empty stack to fail")
+			}
+
+			for i := 1; i <= 4; i++ {
+				s.Push(i)
+			}
+			if s.Len() != 4 {
+				t.Errorf("Expected len 4, but got %d", s.Len())
+			}
+			if got := s.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3, 4}) {
+				t.Errorf("Expected [1 2 3 4], but got %v", got)
+			}
+
+			var ranged []int
+			s.Range(func(v int) bool {
+				ranged = append(ranged, v)
+				return v > 3
+			})
+			if !reflect.DeepEqual(ranged, []int{4, 3}) {
+				t.Errorf("Expected [4 3], but got %v", ranged)
+			}
+
+			if v, _ := s.Pop(); v != 4 {
+				t.Errorf("Expected 4, but got %d", v)
+			}
+			if v, _ := s.Peek(); v != 3 {
+				t.Errorf("Expected 3, but got %d", v)
+			}
+			for !s.IsEmpty() {
+				s.Pop()
+			}
+			if s.Len() != 0 {
+				t.Errorf("Expected len 0, but got %d", s.Len())
+			}
+		})
+	}
+}
+
+func TestStack_Clone(t *testing.T) {
+	s := New[int]()
+	s.Push(1)
+	s.Push(2)
+	c := s.Clone()
+	c.Push(3)
+	if s.Len() != 2 || c.Len() != 3 {
+		t.Errorf("Expected clone to be independent, got %v and %v", s.ToSlice(), c.ToSlice())
+	}
+
+	ss := NewSliceStack[int](0)
+	ss.Push(1)
+	sc := ss.Clone()
+	sc.Pop()
+	if ss.Len() != 1 || sc.Len() != 0 {
+		t.Errorf("Expected clone to be independent, got %v and %v", ss.ToSlice(), sc.ToSlice())
+	}
+}
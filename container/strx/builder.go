@@ -0,0 +1,160 @@
+package str
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// KeyBuilder joins values of different types with a separator, formatting
+// numbers, bools and times with strconv and time instead of fmt.
+// It is meant for hot paths that build many small keys, such as Redis keys:
+//
+//	key := NewKeyBuilder(":", 32).Str("user").Int(42).Str("profile").String() // "user:42:profile"
+//
+// Like strings.Builder, String does not copy the buffer, so a KeyBuilder
+// can only be appended to or Reset after String is called.
+// The zero value uses no separator.
+type KeyBuilder struct {
+	buf   []byte
+	sep   string
+	parts int
+}
+
+// NewKeyBuilder returns a KeyBuilder joining parts with sep,
+// with an initial buffer of size bytes.
+func NewKeyBuilder(sep string, size int) *KeyBuilder {
+	if size < 0 {
+		size = 0
+	}
+	return &KeyBuilder{buf: make([]byte, 0, size), sep: sep}
+}
+
+func (kb *KeyBuilder) next() {
+	if kb.parts > 0 {
+		kb.buf = append(kb.buf, kb.sep...)
+	}
+	kb.parts++
+}
+
+func (kb *KeyBuilder) Str(s string) *KeyBuilder {
+	kb.next()
+	kb.buf = append(kb.buf, s...)
+	return kb
+}
+
+func (kb *KeyBuilder) Bytes(b []byte) *KeyBuilder {
+	kb.next()
+	kb.buf = append(kb.buf, b...)
+	return kb
+}
+
+func (kb *KeyBuilder) Int(n int64) *KeyBuilder {
+	kb.next()
+	kb.buf = strconv.AppendInt(kb.buf, n, 10)
+	return kb
+}
+
+func (kb *KeyBuilder) Uint(n uint64) *KeyBuilder {
+	kb.next()
+	kb.buf = strconv.AppendUint(kb.buf, n, 10)
+	return kb
+}
+
+// Float appends f in the shortest form that represents it exactly.
+func (kb *KeyBuilder) Float(f float64) *KeyBuilder {
+	kb.next()
+	kb.buf = strconv.AppendFloat(kb.buf, f, 'f', -1, 64)
+	return kb
+}
+
+func (kb *KeyBuilder) Bool(b bool) *KeyBuilder {
+	kb.next()
+	kb.buf = strconv.AppendBool(kb.buf, b)
+	return kb
+}
+
+// Time appends t formatted with layout, such as timex.DateLayout.
+func (kb *KeyBuilder) Time(t time.Time, layout string) *KeyBuilder {
+	kb.next()
+	kb.buf = t.AppendFormat(kb.buf, layout)
+	return kb
+}
+
+// Any appends v, choosing the matching method for strings, byte slices,
+// integers, floats, bools, time.Time (RFC 3339) and time.Duration.
+// Other types fall back to fmt.Stringer, error and finally fmt.Sprint.
+func (kb *KeyBuilder) Any(v any) *KeyBuilder {
+	switch x := v.(type) {
+	case string:
+		return kb.Str(x)
+	case []byte:
+		return kb.Bytes(x)
+	case int:
+		return kb.Int(int64(x))
+	case int8:
+		return kb.Int(int64(x))
+	case int16:
+		return kb.Int(int64(x))
+	case int32:
+		return kb.Int(int64(x))
+	case int64:
+		return kb.Int(x)
+	case uint:
+		return kb.Uint(uint64(x))
+	case uint8:
+		return kb.Uint(uint64(x))
+	case uint16:
+		return kb.Uint(uint64(x))
+	case uint32:
+		return kb.Uint(uint64(x))
+	case uint64:
+		return kb.Uint(x)
+	case float32:
+		kb.next()
+		kb.buf = strconv.AppendFloat(kb.buf, float64(x), 'f', -1, 32)
+		return kb
+	case float64:
+		return kb.Float(x)
+	case bool:
+		return kb.Bool(x)
+	case time.Time:
+		return kb.Time(x, time.RFC3339)
+	case time.Duration:
+		return kb.Str(x.String())
+	case fmt.Stringer:
+		return kb.Str(x.String())
+	case error:
+		return kb.Str(x.Error())
+	}
+	return kb.Str(fmt.Sprint(v))
+}
+
+// Len returns the number of bytes written.
+func (kb *KeyBuilder) Len() int {
+	return len(kb.buf)
+}
+
+// String returns the key without copying the buffer.
+func (kb *KeyBuilder) String() string {
+	return *(*string)(unsafe.Pointer(&kb.buf))
+}
+
+// Reset empties the builder. The buffer is dropped, not reused,
+// because strings returned by String still point to it.
+func (kb *KeyBuilder) Reset() {
+	kb.buf = nil
+	kb.parts = 0
+}
+
+// Key joins parts with sep, see KeyBuilder.Any for how each part is formatted.
+//
+// Key(":", "order", 1024, true) => "order:1024:true"
+func Key(sep string, parts ...any) string {
+	kb := KeyBuilder{buf: make([]byte, 0, 16*len(parts)), sep: sep}
+	for _, p := range parts {
+		kb.Any(p)
+	}
+	return kb.String()
+}
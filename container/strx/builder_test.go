@@ -0,0 +1,82 @@
+package str
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestKeyBuilder(t *testing.T) {
+	at := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+
+	kb := NewKeyBuilder(":", 32)
+	kb.Str("user").Int(-42).Uint(7).Float(1.5).Bool(true).Time(at, "2006-01-02").Bytes([]byte("end"))
+	if got := kb.String(); got != "user:-42:7:1.5:true:2024-03-01:end" {
+		t.Errorf("KeyBuilder = %q", got)
+	}
+	if kb.Len() != len(kb.String()) {
+		t.Errorf("Len = %d", kb.Len())
+	}
+
+	s := kb.String()
+	kb.Reset()
+	kb.Str("next")
+	if s != "user:-42:7:1.5:true:2024-03-01:end" || kb.String() != "next" {
+		t.Errorf("Reset changed a returned string: %q, %q", s, kb.String())
+	}
+
+	var zero KeyBuilder
+	zero.Str("a").Int(1)
+	if zero.String() != "a1" {
+		t.Errorf("zero KeyBuilder = %q", zero.String())
+	}
+}
+
+type keyStringer struct{}
+
+func (keyStringer) String() string { return "stringer" }
+
+func TestKey(t *testing.T) {
+	at := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		parts []any
+		want  string
+	}{
+		{parts: nil, want: ""},
+		{parts: []any{"order", 1024, true}, want: "order:1024:true"},
+		{parts: []any{int8(-1), uint16(2), int64(3), uint64(4)}, want: "-1:2:3:4"},
+		{parts: []any{float32(0.1), 2.25}, want: "0.1:2.25"},
+		{parts: []any{at, time.Second}, want: "2024-03-01T08:30:00Z:1s"},
+		{parts: []any{keyStringer{}, errors.New("err"), []int{1}}, want: "stringer:err:[1]"},
+	}
+	for _, tt := range tests {
+		if got := Key(":", tt.parts...); got != tt.want {
+			t.Errorf("Key(%v) = %q, want %q", tt.parts, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkKey(b *testing.B) {
+	b.Run("KeyBuilder", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = NewKeyBuilder(":", 32).Str("user").Int(int64(i)).Str("profile").String()
+		}
+	})
+	b.Run("Key", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Key(":", "user", i, "profile")
+		}
+	})
+	b.Run("Concat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Concat([]string{"user", strconv.Itoa(i), "profile"}, ":")
+		}
+	})
+	b.Run("Sprintf", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = fmt.Sprintf("%s:%d:%s", "user", i, "profile")
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package str
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SplitWords splits s into words for identifier conversion.
+//
+// Words are separated by any rune that is not a letter or digit, and by case
+// changes. A run of upper case letters is kept as one word, its last letter
+// starts a new word when followed by a lower case letter. Digits stay with the
+// word before them.
+//
+// "HTTPServer_v2 loadID" => ["HTTP", "Server", "v2", "load", "ID"]
+func SplitWords(s string) []string {
+	runes := []rune(s)
+	words := make([]string, 0, 4)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+
+		if unicode.IsUpper(r) {
+			prev := runes[i-1]
+			acronymEnd := unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || acronymEnd {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// CamelCase converts s to lower camel case.
+//
+// "HTTP server_id" => "httpServerId"
+func CamelCase(s string) string {
+	words := SplitWords(s)
+	for i := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(words[i])
+		} else {
+			words[i] = Capitalize(strings.ToLower(words[i]))
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// PascalCase converts s to upper camel case.
+//
+// "http_server_id" => "HttpServerId"
+func PascalCase(s string) string {
+	words := SplitWords(s)
+	for i := range words {
+		words[i] = Capitalize(strings.ToLower(words[i]))
+	}
+	return strings.Join(words, "")
+}
+
+// SnakeCase converts s to lower case words joined by underscores.
+//
+// "HTTPServer" => "http_server"
+func SnakeCase(s string) string {
+	return joinWords(s, "_", strings.ToLower)
+}
+
+// KebabCase converts s to lower case words joined by hyphens.
+//
+// "HTTPServer" => "http-server"
+func KebabCase(s string) string {
+	return joinWords(s, "-", strings.ToLower)
+}
+
+// ScreamingSnake converts s to upper case words joined by underscores.
+//
+// "httpServer" => "HTTP_SERVER"
+func ScreamingSnake(s string) string {
+	return joinWords(s, "_", strings.ToUpper)
+}
+
+func joinWords(s, sep string, f func(string) string) string {
+	words := SplitWords(s)
+	for i := range words {
+		words[i] = f(words[i])
+	}
+	return strings.Join(words, sep)
+}
+
+// Capitalize converts the first rune of s to title case and keeps the rest.
+func Capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || r == utf8.RuneError {
+		return s
+	}
+	upper := unicode.ToTitle(r)
+	if upper == r {
+		return s
+	}
+	return string(upper) + s[size:]
+}
+
+// Uncapitalize converts the first rune of s to lower case and keeps the rest.
+func Uncapitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || r == utf8.RuneError {
+		return s
+	}
+	lower := unicode.ToLower(r)
+	if lower == r {
+		return s
+	}
+	return string(lower) + s[size:]
+}
+
+// Title capitalizes the first letter of each space separated word
+// and lowers the rest, the spacing of s is kept.
+//
+// "hello WORLD" => "Hello World"
+func Title(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	inWord := false
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			inWord = false
+		case inWord:
+			r = unicode.ToLower(r)
+		default:
+			r = unicode.ToTitle(r)
+			inWord = true
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
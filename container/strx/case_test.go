@@ -0,0 +1,120 @@
+package str
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{input: "", want: []string{}},
+		{input: "hello", want: []string{"hello"}},
+		{input: "helloWorld", want: []string{"hello", "World"}},
+		{input: "HTTPServer", want: []string{"HTTP", "Server"}},
+		{input: "userID", want: []string{"user", "ID"}},
+		{input: "snake_case-and kebab", want: []string{"snake", "case", "and", "kebab"}},
+		{input: "ipv4Address", want: []string{"ipv4", "Address"}},
+		{input: "HTTP2Server", want: []string{"HTTP2", "Server"}},
+		{input: "  __leading", want: []string{"leading"}},
+		{input: "ÜberStraße", want: []string{"Über", "Straße"}},
+		{input: "用户Name", want: []string{"用户", "Name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := SplitWords(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitWords(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaseConversion(t *testing.T) {
+	tests := []struct {
+		input                               string
+		camel, pascal, snake, kebab, scream string
+	}{
+		{
+			input: "HTTPServer",
+			camel: "httpServer", pascal: "HttpServer", snake: "http_server", kebab: "http-server", scream: "HTTP_SERVER",
+		},
+		{
+			input: "user_id",
+			camel: "userId", pascal: "UserId", snake: "user_id", kebab: "user-id", scream: "USER_ID",
+		},
+		{
+			input: "created-at time",
+			camel: "createdAtTime", pascal: "CreatedAtTime", snake: "created_at_time", kebab: "created-at-time",
+			scream: "CREATED_AT_TIME",
+		},
+		{
+			input: "page2Size",
+			camel: "page2Size", pascal: "Page2Size", snake: "page2_size", kebab: "page2-size", scream: "PAGE2_SIZE",
+		},
+		{
+			input: "ÄpfelBäume",
+			camel: "äpfelBäume", pascal: "ÄpfelBäume", snake: "äpfel_bäume", kebab: "äpfel-bäume", scream: "ÄPFEL_BÄUME",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := CamelCase(tt.input); got != tt.camel {
+				t.Errorf("CamelCase(%q) = %q, want %q", tt.input, got, tt.camel)
+			}
+			if got := PascalCase(tt.input); got != tt.pascal {
+				t.Errorf("PascalCase(%q) = %q, want %q", tt.input, got, tt.pascal)
+			}
+			if got := SnakeCase(tt.input); got != tt.snake {
+				t.Errorf("SnakeCase(%q) = %q, want %q", tt.input, got, tt.snake)
+			}
+			if got := KebabCase(tt.input); got != tt.kebab {
+				t.Errorf("KebabCase(%q) = %q, want %q", tt.input, got, tt.kebab)
+			}
+			if got := ScreamingSnake(tt.input); got != tt.scream {
+				t.Errorf("ScreamingSnake(%q) = %q, want %q", tt.input, got, tt.scream)
+			}
+		})
+	}
+}
+
+func TestCapitalize(t *testing.T) {
+	cases := map[string]string{
+		"":      "",
+		"hello": "Hello",
+		"Hello": "Hello",
+		"élan":  "Élan",
+		"1abc":  "1abc",
+		"中文":    "中文",
+	}
+	for in, want := range cases {
+		if got := Capitalize(in); got != want {
+			t.Errorf("Capitalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if got := Uncapitalize("Hello"); got != "hello" {
+		t.Errorf("Uncapitalize(Hello) = %q, want hello", got)
+	}
+}
+
+func TestTitle(t *testing.T) {
+	cases := map[string]string{
+		"":                 "",
+		"hello WORLD":      "Hello World",
+		"  the  go\tlang ": "  The  Go\tLang ",
+		"élan vital":       "Élan Vital",
+	}
+	for in, want := range cases {
+		if got := Title(in); got != want {
+			t.Errorf("Title(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func BenchmarkSnakeCase(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = SnakeCase("HTTPServerRequestID")
+	}
+}
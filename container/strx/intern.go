@@ -0,0 +1,97 @@
+package str
+
+import "sync"
+
+// Interner deduplicates strings with many repetitions, such as host names
+// and tags, so equal values share one backing array.
+//
+// Memory is bounded by keeping two generations: new strings go into the
+// current generation, and once it holds half of maxBytes it becomes the
+// previous generation and the old previous one is dropped. Strings found
+// in the previous generation are moved back to the current one, so values
+// in use survive rotations. An Interner is safe for concurrent use.
+type Interner struct {
+	lock     sync.Mutex
+	maxBytes int
+	curBytes int
+	cur      map[string]string
+	prev     map[string]string
+}
+
+// NewInterner returns an Interner holding about maxBytes bytes of strings,
+// not counting map overhead.
+func NewInterner(maxBytes int) *Interner {
+	if maxBytes < 2 {
+		maxBytes = 2
+	}
+	return &Interner{
+		maxBytes: maxBytes,
+		cur:      make(map[string]string),
+		prev:     make(map[string]string),
+	}
+}
+
+// Intern returns the canonical copy of s. Strings longer than half of the
+// memory bound are returned unchanged.
+func (in *Interner) Intern(s string) string {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+
+	if v, ok := in.cur[s]; ok {
+		return v
+	}
+	if v, ok := in.prev[s]; ok {
+		in.add(v)
+		return v
+	}
+	if len(s) > in.maxBytes/2 {
+		return s
+	}
+	in.add(s)
+	return s
+}
+
+// InternBytes is like Intern but takes a byte slice, such as a field parsed
+// from a request, and only allocates when the value is not interned yet.
+func (in *Interner) InternBytes(b []byte) string {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+
+	// map lookups with string(b) do not allocate
+	if v, ok := in.cur[string(b)]; ok {
+		return v
+	}
+	if v, ok := in.prev[string(b)]; ok {
+		in.add(v)
+		return v
+	}
+	s := string(b)
+	if len(s) <= in.maxBytes/2 {
+		in.add(s)
+	}
+	return s
+}
+
+func (in *Interner) add(s string) {
+	if in.curBytes+len(s) > in.maxBytes/2 {
+		in.prev = in.cur
+		in.cur = make(map[string]string, len(in.prev))
+		in.curBytes = 0
+	}
+	in.cur[s] = s
+	in.curBytes += len(s)
+}
+
+// Len returns the number of interned strings.
+func (in *Interner) Len() int {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+
+	n := len(in.cur)
+	for k := range in.prev {
+		if _, ok := in.cur[k]; !ok {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,61 @@
+package str
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestInterner(t *testing.T) {
+	in := NewInterner(64)
+
+	a := in.Intern(string([]byte("host-1")))
+	b := in.InternBytes([]byte("host-1"))
+	if a != b || stringData(a) != stringData(b) {
+		t.Error("Expected interned strings to share memory")
+	}
+	if in.Len() != 1 {
+		t.Errorf("Expected 1 interned string, got %d", in.Len())
+	}
+
+	long := strings.Repeat("x", 40)
+	if got := in.Intern(long); got != long || in.Len() != 1 {
+		t.Errorf("Expected long string not to be interned, len %d", in.Len())
+	}
+
+	// fill the memory bound, older unused entries are dropped
+	for i := 0; i < 100; i++ {
+		in.Intern("tag-" + strconv.Itoa(i))
+		in.Intern(a)
+	}
+	if in.Len() > 64/len("tag-0")+1 {
+		t.Errorf("Expected a bounded interner, got %d entries", in.Len())
+	}
+	if got := in.Intern(string([]byte("host-1"))); stringData(got) != stringData(a) {
+		t.Error("Expected a string in use to survive rotations")
+	}
+}
+
+func BenchmarkInterner(b *testing.B) {
+	in := NewInterner(1 << 20)
+	hosts := [][]byte{[]byte("api.example.com"), []byte("www.example.com"), []byte("cdn.example.com")}
+
+	b.Run("InternBytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = in.InternBytes(hosts[i%len(hosts)])
+		}
+	})
+	b.Run("string", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = string(hosts[i%len(hosts)])
+		}
+	})
+}
@@ -0,0 +1,337 @@
+package str
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// MaskRune is the rune used by the built-in maskers.
+const MaskRune = '*'
+
+// Mask replaces the runes of s with mask, keeping keepPrefix runes at the start
+// and keepSuffix runes at the end. If s is too short to keep both, every rune is
+// masked so a short value is never revealed.
+//
+// Mask("13812345678", 3, 4, '*') => "138****5678"
+func Mask(s string, keepPrefix, keepSuffix int, mask rune) string {
+	n := utf8.RuneCountInString(s)
+	if keepPrefix < 0 {
+		keepPrefix = 0
+	}
+	if keepSuffix < 0 {
+		keepSuffix = 0
+	}
+	if keepPrefix+keepSuffix >= n {
+		keepPrefix, keepSuffix = 0, 0
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	i := 0
+	for _, r := range s {
+		if i < keepPrefix || i >= n-keepSuffix {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(mask)
+		}
+		i++
+	}
+	return b.String()
+}
+
+// PII kinds, used as mask tag values and returned by DetectPII.
+const (
+	PIIPhone    = "phone"
+	PIIEmail    = "email"
+	PIIIDCard   = "idcard"
+	PIIBankCard = "bankcard"
+	PIIToken    = "token"
+)
+
+var (
+	mobileRegexp = regexp.MustCompile(`^(?:\+?86[- ]?)?1[3-9]\d{9}$`)
+	emailRegexp  = regexp.MustCompile(`^[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}$`)
+)
+
+// IsChineseMobile reports whether s is a mainland China mobile number,
+// optionally prefixed with +86 or 86.
+func IsChineseMobile(s string) bool {
+	return mobileRegexp.MatchString(s)
+}
+
+// IsEmail reports whether s looks like an email address.
+func IsEmail(s string) bool {
+	return emailRegexp.MatchString(s)
+}
+
+var (
+	idCardWeights = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	idCardCodes   = "10X98765432"
+)
+
+// IsChineseIDCard reports whether s is a valid 18-digit resident identity card
+// number: 17 digits with a valid birth date and a matching check code.
+func IsChineseIDCard(s string) bool {
+	if len(s) != 18 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 17; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		sum += int(s[i]-'0') * idCardWeights[i]
+	}
+	if _, err := time.Parse("20060102", s[6:14]); err != nil {
+		return false
+	}
+
+	check := s[17]
+	if check == 'x' {
+		check = 'X'
+	}
+	return check == idCardCodes[sum%11]
+}
+
+// IsBankCard reports whether s is a 12 to 19 digit card number passing the Luhn check.
+// Spaces between digit groups are allowed.
+func IsBankCard(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) < 12 || len(s) > 19 {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		d := int(s[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// DetectPII returns the kind of personal data s holds, or "" if none is recognized.
+// Tokens have no fixed format and are never detected.
+func DetectPII(s string) string {
+	switch {
+	case IsChineseMobile(s):
+		return PIIPhone
+	case IsEmail(s):
+		return PIIEmail
+	case IsChineseIDCard(s):
+		return PIIIDCard
+	case IsBankCard(s):
+		return PIIBankCard
+	}
+	return ""
+}
+
+// MaskPhone keeps the first 3 and last 4 digits of a phone number.
+//
+// "13812345678" => "138****5678"
+func MaskPhone(s string) string {
+	prefix := ""
+	if n := len(s) - 11; n > 0 && IsChineseMobile(s) {
+		prefix, s = s[:n], s[n:]
+	}
+	return prefix + Mask(s, 3, 4, MaskRune)
+}
+
+// MaskEmail keeps the first rune of the local part and the domain.
+//
+// "alice@example.com" => "a****@example.com"
+func MaskEmail(s string) string {
+	at := strings.LastIndexByte(s, '@')
+	if at < 0 {
+		return Mask(s, 0, 0, MaskRune)
+	}
+	local := s[:at]
+	if utf8.RuneCountInString(local) == 1 {
+		return string(MaskRune) + s[at:]
+	}
+	return Mask(local, 1, 0, MaskRune) + s[at:]
+}
+
+// MaskIDCard keeps the first 3 and last 4 characters of an identity card number.
+//
+// "110101199003071234" => "110***********1234"
+func MaskIDCard(s string) string {
+	return Mask(s, 3, 4, MaskRune)
+}
+
+// MaskBankCard keeps the first 4 and last 4 digits of a card number,
+// spaces between digit groups are kept. Numbers of 8 digits or less are masked entirely.
+//
+// "6222 0212 3456 7890" => "6222 **** **** 7890"
+func MaskBankCard(s string) string {
+	digits := utf8.RuneCountInString(s) - strings.Count(s, " ")
+	keep := 4
+	if digits <= 8 {
+		keep = 0
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	i := 0
+	for _, r := range s {
+		if r == ' ' {
+			b.WriteRune(r)
+			continue
+		}
+		if i < keep || i >= digits-keep {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(MaskRune)
+		}
+		i++
+	}
+	return b.String()
+}
+
+// MaskToken keeps the first and last 4 characters of a secret longer than 16
+// characters and masks shorter ones entirely.
+func MaskToken(s string) string {
+	if utf8.RuneCountInString(s) <= 16 {
+		return Mask(s, 0, 0, MaskRune)
+	}
+	return Mask(s, 4, 4, MaskRune)
+}
+
+var maskers = struct {
+	sync.RWMutex
+	m map[string]func(string) string
+}{
+	m: map[string]func(string) string{
+		PIIPhone:    MaskPhone,
+		PIIEmail:    MaskEmail,
+		PIIIDCard:   MaskIDCard,
+		PIIBankCard: MaskBankCard,
+		PIIToken:    MaskToken,
+	},
+}
+
+// RegisterMasker adds or replaces the masker used for kind,
+// so MaskStruct can handle custom tags such as `mask:"name"`.
+func RegisterMasker(kind string, f func(string) string) {
+	maskers.Lock()
+	maskers.m[kind] = f
+	maskers.Unlock()
+}
+
+func lookupMasker(kind string) (func(string) string, bool) {
+	maskers.RLock()
+	f, ok := maskers.m[kind]
+	maskers.RUnlock()
+	return f, ok
+}
+
+// MaskPII masks s with the masker of its detected kind, see DetectPII.
+// Values of unknown kind are returned unchanged.
+func MaskPII(s string) string {
+	if f, ok := lookupMasker(DetectPII(s)); ok {
+		return f(s)
+	}
+	return s
+}
+
+// MaskStruct masks, in place, the string fields of the struct v points to
+// that carry a mask tag naming a registered masker:
+//
+//	type User struct {
+//		Phone string `mask:"phone"`
+//		Email string `mask:"email"`
+//		Note  string `mask:"auto"` // detected with DetectPII
+//	}
+//
+// Nested structs, pointers, slices and arrays are walked. Fields of type
+// string, *string and []string can be tagged, unexported fields are skipped.
+func MaskStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("mask: v must be a non-nil pointer to a struct")
+	}
+	return maskValue(rv.Elem(), map[uintptr]bool{rv.Pointer(): true})
+}
+
+// maskValue walks rv, seen holds the visited pointers so cyclic data terminates.
+func maskValue(rv reflect.Value, seen map[uintptr]bool) error {
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() || seen[rv.Pointer()] {
+			return nil
+		}
+		seen[rv.Pointer()] = true
+		return maskValue(rv.Elem(), seen)
+	case reflect.Interface:
+		if !rv.IsNil() {
+			return maskValue(rv.Elem(), seen)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := maskValue(rv.Index(i), seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		rt := rv.Type()
+		for i := 0; i < rt.NumField(); i++ {
+			field := rv.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			kind, ok := rt.Field(i).Tag.Lookup("mask")
+			if !ok || kind == "" || kind == "-" {
+				if err := maskValue(field, seen); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := maskField(field, kind); err != nil {
+				return fmt.Errorf("mask: field %s: %w", rt.Field(i).Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func maskField(field reflect.Value, kind string) error {
+	f := MaskPII
+	if kind != "auto" {
+		var ok bool
+		if f, ok = lookupMasker(kind); !ok {
+			return fmt.Errorf("unknown mask kind %q", kind)
+		}
+	}
+
+	switch {
+	case field.Kind() == reflect.String:
+		field.SetString(f(field.String()))
+	case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.String:
+		if !field.IsNil() {
+			field.Elem().SetString(f(field.Elem().String()))
+		}
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		for i := 0; i < field.Len(); i++ {
+			field.Index(i).SetString(f(field.Index(i).String()))
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package str
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		input          string
+		prefix, suffix int
+		mask           rune
+		want           string
+	}{
+		{input: "13812345678", prefix: 3, suffix: 4, mask: '*', want: "138****5678"},
+		{input: "secret", prefix: 0, suffix: 0, mask: '#', want: "######"},
+		{input: "abc", prefix: 2, suffix: 2, mask: '*', want: "***"},
+		{input: "张三丰", prefix: 1, suffix: 0, mask: '*', want: "张**"},
+		{input: "", prefix: 1, suffix: 1, mask: '*', want: ""},
+		{input: "abcd", prefix: -1, suffix: 1, mask: '*', want: "***d"},
+	}
+	for _, tt := range tests {
+		if got := Mask(tt.input, tt.prefix, tt.suffix, tt.mask); got != tt.want {
+			t.Errorf("Mask(%q, %d, %d) = %q, want %q", tt.input, tt.prefix, tt.suffix, got, tt.want)
+		}
+	}
+}
+
+func TestDetectPII(t *testing.T) {
+	tests := map[string]string{
+		"13812345678":         PIIPhone,
+		"+86 13812345678":     PIIPhone,
+		"12812345678":         "",
+		"alice@example.com":   PIIEmail,
+		"alice@localhost":     "",
+		"11010519491231002X":  PIIIDCard,
+		"11010519491231002x":  PIIIDCard,
+		"110105194912310021":  "",
+		"110105194913310028":  "",
+		"4111111111111111":    PIIBankCard,
+		"4111 1111 1111 1111": PIIBankCard,
+		"4111111111111112":    "",
+		"hello":               "",
+	}
+	for input, want := range tests {
+		if got := DetectPII(input); got != want {
+			t.Errorf("DetectPII(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestMaskers(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(string) string
+		in   string
+		want string
+	}{
+		{name: "phone", f: MaskPhone, in: "13812345678", want: "138****5678"},
+		{name: "phone with prefix", f: MaskPhone, in: "+8613812345678", want: "+86138****5678"},
+		{name: "email", f: MaskEmail, in: "alice@example.com", want: "a****@example.com"},
+		{name: "short email", f: MaskEmail, in: "a@example.com", want: "*@example.com"},
+		{name: "id card", f: MaskIDCard, in: "11010519491231002X", want: "110***********002X"},
+		{name: "bank card", f: MaskBankCard, in: "6222 0212 3456 7890", want: "6222 **** **** 7890"},
+		{name: "bank card plain", f: MaskBankCard, in: "4111111111111111", want: "4111********1111"},
+		{name: "short bank card", f: MaskBankCard, in: "12345678", want: "********"},
+		{name: "token", f: MaskToken, in: "sk-abcdefghijklmnopqrst", want: "sk-a***************qrst"},
+		{name: "short token", f: MaskToken, in: "abcd1234", want: "********"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := MaskPII("alice@example.com"); got != "a****@example.com" {
+		t.Errorf("MaskPII = %q", got)
+	}
+	if got := MaskPII("plain text"); got != "plain text" {
+		t.Errorf("MaskPII = %q", got)
+	}
+}
+
+type maskAddress struct {
+	Contact string `mask:"phone"`
+	City    string
+}
+
+type maskUser struct {
+	Name     string `mask:"name"`
+	Phone    string `mask:"phone"`
+	Email    *string
+	Backup   *string  `mask:"email"`
+	Cards    []string `mask:"bankcard"`
+	Note     string   `mask:"auto"`
+	Address  maskAddress
+	Previous []*maskAddress
+	Self     *maskUser
+	secret   string `mask:"token"`
+}
+
+func TestMaskStruct(t *testing.T) {
+	RegisterMasker("name", func(s string) string { return Mask(s, 1, 0, MaskRune) })
+
+	email := "bob@example.com"
+	u := &maskUser{
+		Name:     "张三丰",
+		Phone:    "13812345678",
+		Email:    &email,
+		Backup:   &email,
+		Cards:    []string{"4111111111111111"},
+		Note:     "13900001111",
+		Address:  maskAddress{Contact: "13700001111", City: "北京"},
+		Previous: []*maskAddress{{Contact: "13600001111"}, nil},
+		secret:   "keep",
+	}
+	u.Self = u
+
+	if err := MaskStruct(u); err != nil {
+		t.Fatalf("MaskStruct error: %v", err)
+	}
+	checks := map[string]string{
+		u.Name:                "张**",
+		u.Phone:               "138****5678",
+		*u.Backup:             "b**@example.com",
+		u.Cards[0]:            "4111********1111",
+		u.Note:                "139****1111",
+		u.Address.Contact:     "137****1111",
+		u.Address.City:        "北京",
+		u.Previous[0].Contact: "136****1111",
+		u.secret:              "keep",
+	}
+	for got, want := range checks {
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+
+	if err := MaskStruct(*u); err == nil {
+		t.Error("Expected error for non-pointer")
+	}
+	bad := struct {
+		Age int `mask:"phone"`
+	}{}
+	if err := MaskStruct(&bad); err == nil || !strings.Contains(err.Error(), "Age") {
+		t.Errorf("Expected unsupported type error, got %v", err)
+	}
+	unknown := struct {
+		S string `mask:"nope"`
+	}{}
+	if err := MaskStruct(&unknown); err == nil {
+		t.Error("Expected unknown kind error")
+	}
+}
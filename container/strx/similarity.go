@@ -0,0 +1,365 @@
+package str
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// Levenshtein returns the edit distance between a and b, counted in runes:
+// the number of insertions, deletions and substitutions to turn a into b.
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// DamerauLevenshtein is like Levenshtein but also counts a transposition of
+// two adjacent runes as one edit. It computes the optimal string alignment
+// distance, so a substring is never edited more than once.
+//
+// "ab" => "ba" is 1, while Levenshtein gives 2.
+func DamerauLevenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// three rows are needed to look back over a transposition
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] && prev2[j-2]+1 < curr[j] {
+				curr[j] = prev2[j-2] + 1
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// Similarity returns the Levenshtein distance normalized to [0, 1],
+// 1 means a and b are equal.
+func Similarity(a, b string) float64 {
+	la, lb := len([]rune(a)), len([]rune(b))
+	if la < lb {
+		la = lb
+	}
+	if la == 0 {
+		return 1
+	}
+	return 1 - float64(Levenshtein(a, b))/float64(la)
+}
+
+// Jaro returns the Jaro similarity of a and b in [0, 1], 1 means equal.
+func Jaro(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := len(ra)
+	if len(rb) > window {
+		window = len(rb)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		lo, hi := i-window, i+window+1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(rb) {
+			hi = len(rb)
+		}
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// JaroWinkler returns the Jaro-Winkler similarity of a and b in [0, 1].
+// It boosts the Jaro similarity of strings sharing a prefix of up to 4 runes,
+// which suits short strings such as names and parameter keys.
+func JaroWinkler(a, b string) float64 {
+	sim := Jaro(a, b)
+
+	ra, rb := []rune(a), []rune(b)
+	prefix := 0
+	for prefix < len(ra) && prefix < len(rb) && prefix < 4 && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return sim + float64(prefix)*0.1*(1-sim)
+}
+
+// LongestCommonSubsequence returns the longest sequence of runes that appears
+// in both a and b in the same order, not necessarily contiguous.
+//
+// "ABCBDAB", "BDCABA" => "BCBA"
+func LongestCommonSubsequence(a, b string) string {
+	ra, rb := []rune(a), []rune(b)
+	dp := make([][]int, len(ra)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(rb)+1)
+	}
+	for i := len(ra) - 1; i >= 0; i-- {
+		for j := len(rb) - 1; j >= 0; j-- {
+			if ra[i] == rb[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else if dp[i+1][j] >= dp[i][j+1] {
+				dp[i][j] = dp[i+1][j]
+			} else {
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+
+	lcs := make([]rune, 0, dp[0][0])
+	for i, j := 0, 0; i < len(ra) && j < len(rb); {
+		switch {
+		case ra[i] == rb[j]:
+			lcs = append(lcs, ra[i])
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return string(lcs)
+}
+
+// LongestCommonSubstring returns the longest contiguous run of runes found in
+// both a and b, the first one in a if there are several.
+//
+// "hello world", "yellow" => "ello"
+func LongestCommonSubstring(a, b string) string {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	best, end := 0, 0
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			if ra[i-1] == rb[j-1] {
+				curr[j] = prev[j-1] + 1
+				if curr[j] > best {
+					best, end = curr[j], i
+				}
+			} else {
+				curr[j] = 0
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return string(ra[end-best : end])
+}
+
+// NGrams returns the distinct rune n-grams of s. A string shorter than n
+// yields itself as the only gram, an empty string yields none.
+func NGrams(s string, n int) map[string]struct{} {
+	if n < 1 {
+		n = 1
+	}
+	runes := []rune(s)
+	grams := make(map[string]struct{})
+	if len(runes) == 0 {
+		return grams
+	}
+	if len(runes) < n {
+		grams[s] = struct{}{}
+		return grams
+	}
+	for i := 0; i+n <= len(runes); i++ {
+		grams[string(runes[i:i+n])] = struct{}{}
+	}
+	return grams
+}
+
+// NGramJaccard returns the Jaccard similarity of the rune n-gram sets of a and b,
+// the size of the intersection divided by the size of the union.
+func NGramJaccard(a, b string, n int) float64 {
+	ga, gb := NGrams(a, n), NGrams(b, n)
+	if len(ga) == 0 && len(gb) == 0 {
+		return 1
+	}
+
+	inter := 0
+	for g := range ga {
+		if _, ok := gb[g]; ok {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(ga)+len(gb)-inter)
+}
+
+func hashGram(g string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(g))
+	return h.Sum64()
+}
+
+// SimHash returns a 64-bit fingerprint of s built from its rune n-grams.
+// Similar strings get fingerprints with a small HammingDistance,
+// which makes it cheap to find near-duplicate titles.
+func SimHash(s string, n int) uint64 {
+	var weights [64]int
+	for g := range NGrams(s, n) {
+		h := hashGram(g)
+		for i := range weights {
+			if h&(1<<uint(i)) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	var fp uint64
+	for i, w := range weights {
+		if w > 0 {
+			fp |= 1 << uint(i)
+		}
+	}
+	return fp
+}
+
+// HammingDistance returns the number of differing bits between two fingerprints.
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// MinHash returns a MinHash signature of size k over the rune n-grams of s.
+// The fraction of equal positions in two signatures estimates
+// the NGramJaccard similarity of the strings, see MinHashSimilarity.
+// It returns nil if k is not positive.
+func MinHash(s string, n, k int) []uint64 {
+	if k <= 0 {
+		return nil
+	}
+	sig := make([]uint64, k)
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+	for g := range NGrams(s, n) {
+		h := hashGram(g)
+		for i := range sig {
+			if v := mix64(h ^ uint64(i)*0x9e3779b97f4a7c15); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	return sig
+}
+
+// mix64 is the splitmix64 finalizer, it derives independent hashes from one.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// MinHashSimilarity returns the fraction of equal positions of two signatures
+// made by MinHash with the same k.
+func MinHashSimilarity(a, b []uint64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// ClosestMatch returns the candidate most similar to input by Similarity,
+// and its score. It returns false if there are no candidates.
+// Callers usually apply a threshold to the score before suggesting the match.
+func ClosestMatch(candidates []string, input string) (string, float64, bool) {
+	if len(candidates) == 0 {
+		return "", 0, false
+	}
+
+	best, bestScore := candidates[0], -1.0
+	for _, c := range candidates {
+		if score := Similarity(c, input); score > bestScore {
+			best, bestScore = c, score
+		}
+	}
+	return best, bestScore, true
+}
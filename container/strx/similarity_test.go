@@ -0,0 +1,156 @@
+package str
+
+import (
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-3
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		lev, dl int
+	}{
+		{a: "", b: "", lev: 0, dl: 0},
+		{a: "abc", b: "", lev: 3, dl: 3},
+		{a: "kitten", b: "sitting", lev: 3, dl: 3},
+		{a: "flaw", b: "lawn", lev: 2, dl: 2},
+		{a: "ab", b: "ba", lev: 2, dl: 1},
+		{a: "ca", b: "abc", lev: 3, dl: 3},
+		{a: "中文字", b: "中字文", lev: 2, dl: 1},
+		{a: "héllo", b: "hello", lev: 1, dl: 1},
+	}
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.lev {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.lev)
+		}
+		if got := Levenshtein(tt.b, tt.a); got != tt.lev {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.lev)
+		}
+		if got := DamerauLevenshtein(tt.a, tt.b); got != tt.dl {
+			t.Errorf("DamerauLevenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.dl)
+		}
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b        string
+		jaro, jaroW float64
+	}{
+		{a: "", b: "", jaro: 1, jaroW: 1},
+		{a: "abc", b: "", jaro: 0, jaroW: 0},
+		{a: "MARTHA", b: "MARHTA", jaro: 0.944, jaroW: 0.961},
+		{a: "DIXON", b: "DICKSONX", jaro: 0.767, jaroW: 0.813},
+		{a: "abc", b: "xyz", jaro: 0, jaroW: 0},
+	}
+	for _, tt := range tests {
+		if got := Jaro(tt.a, tt.b); !almostEqual(got, tt.jaro) {
+			t.Errorf("Jaro(%q, %q) = %f, want %f", tt.a, tt.b, got, tt.jaro)
+		}
+		if got := JaroWinkler(tt.a, tt.b); !almostEqual(got, tt.jaroW) {
+			t.Errorf("JaroWinkler(%q, %q) = %f, want %f", tt.a, tt.b, got, tt.jaroW)
+		}
+	}
+}
+
+func TestLongestCommon(t *testing.T) {
+	if got := LongestCommonSubsequence("ABCBDAB", "BDCABA"); len(got) != 4 {
+		t.Errorf("Expected a subsequence of length 4, but got %q", got)
+	}
+	if got := LongestCommonSubsequence("", "abc"); got != "" {
+		t.Errorf("Expected empty subsequence, but got %q", got)
+	}
+	if got := LongestCommonSubsequence("北京大学", "北京理工大学"); got != "北京大学" {
+		t.Errorf("Expected 北京大学, but got %q", got)
+	}
+
+	if got := LongestCommonSubstring("hello world", "yellow"); got != "ello" {
+		t.Errorf("Expected ello, but got %q", got)
+	}
+	if got := LongestCommonSubstring("abc", "xyz"); got != "" {
+		t.Errorf("Expected empty substring, but got %q", got)
+	}
+	if got := LongestCommonSubstring("北京理工大学", "理工科"); got != "理工" {
+		t.Errorf("Expected 理工, but got %q", got)
+	}
+}
+
+func TestNGramJaccard(t *testing.T) {
+	if got := NGramJaccard("night", "nacht", 2); !almostEqual(got, 1.0/7) {
+		t.Errorf("Expected 1/7, but got %f", got)
+	}
+	if got := NGramJaccard("same", "same", 3); got != 1 {
+		t.Errorf("Expected 1, but got %f", got)
+	}
+	if got := NGramJaccard("", "", 2); got != 1 {
+		t.Errorf("Expected 1, but got %f", got)
+	}
+	if got := len(NGrams("ab", 3)); got != 1 {
+		t.Errorf("Expected a single gram, but got %d", got)
+	}
+}
+
+func TestSimHash(t *testing.T) {
+	a := SimHash("Go 1.22 released with range over integers", 3)
+	b := SimHash("Go 1.22 released with range over integer", 3)
+	c := SimHash("Redis cluster failover explained in depth", 3)
+
+	if a != SimHash("Go 1.22 released with range over integers", 3) {
+		t.Error("Expected SimHash to be deterministic")
+	}
+	if near, far := HammingDistance(a, b), HammingDistance(a, c); near >= far {
+		t.Errorf("Expected near duplicates to be closer, got %d and %d", near, far)
+	}
+}
+
+func TestMinHash(t *testing.T) {
+	a := MinHash("the quick brown fox jumps over the lazy dog", 3, 128)
+	b := MinHash("the quick brown fox jumped over the lazy dog", 3, 128)
+	c := MinHash("lorem ipsum dolor sit amet", 3, 128)
+
+	if len(a) != 128 {
+		t.Fatalf("Expected signature of 128, but got %d", len(a))
+	}
+	exact := NGramJaccard("the quick brown fox jumps over the lazy dog", "the quick brown fox jumped over the lazy dog", 3)
+	if got := MinHashSimilarity(a, b); math.Abs(got-exact) > 0.15 {
+		t.Errorf("Expected estimate close to %f, but got %f", exact, got)
+	}
+	if got := MinHashSimilarity(a, c); got > 0.1 {
+		t.Errorf("Expected low similarity, but got %f", got)
+	}
+	if got := MinHashSimilarity(a, a[:1]); got != 0 {
+		t.Errorf("Expected 0 for mismatched signatures, but got %f", got)
+	}
+	if sig := MinHash("abc", 3, -1); sig != nil {
+		t.Errorf("Expected nil for a negative size, but got %v", sig)
+	}
+}
+
+func TestClosestMatch(t *testing.T) {
+	params := []string{"page_size", "page_num", "order_by", "keyword"}
+
+	got, score, ok := ClosestMatch(params, "page_sise")
+	if !ok || got != "page_size" {
+		t.Errorf("Expected page_size, but got %q", got)
+	}
+	if !almostEqual(score, 1-1.0/9) {
+		t.Errorf("Expected score %f, but got %f", 1-1.0/9, score)
+	}
+
+	if got, _, _ := ClosestMatch(params, "keywrod"); got != "keyword" {
+		t.Errorf("Expected keyword, but got %q", got)
+	}
+	if _, _, ok := ClosestMatch(nil, "x"); ok {
+		t.Error("Expected no match for empty candidates")
+	}
+}
+
+func BenchmarkLevenshtein(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Levenshtein("the quick brown fox", "the quack brown fax")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hy-shine/gotiny/container/slice"
 	"golang.org/x/exp/constraints"
 )
 
@@ -31,14 +32,22 @@ func Concat(strs []string, sep string) string {
 	return strings.Join(strs, sep)
 }
 
+// ConcatFunc joins the strings accepted by f with sep, f may also rewrite them.
 func ConcatFunc(strs []string, sep string, f func(string) (string, bool)) string {
-	newStrs := make([]string, 0, len(strs))
+	var b strings.Builder
+	first := true
 	for i := range strs {
-		if s, b := f(strs[i]); b {
-			newStrs = append(newStrs, s)
+		s, ok := f(strs[i])
+		if !ok {
+			continue
 		}
+		if !first {
+			b.WriteString(sep)
+		}
+		b.WriteString(s)
+		first = false
 	}
-	return strings.Join(newStrs, sep)
+	return b.String()
 }
 
 func ToNumber[T constraints.Integer | constraints.Float](str string) (T, error) {
@@ -58,18 +67,13 @@ func ToLower(s *string) {
 	}
 }
 
+// IntToStrings formats s in base 10, see slice.FormatInts.
 func IntToStrings[T constraints.Integer](s []T) []string {
-	strs := make([]string, len(s))
-	for i := range s {
-		strs[i] = strconv.FormatInt(int64(s[i]), 10)
-	}
-	return strs
+	return slice.FormatInts(s, 10)
 }
 
+// FloatToStrings formats s with the smallest precision that represents
+// each value exactly, see slice.FormatFloats.
 func FloatToStrings[T constraints.Float](s []T) []string {
-	strs := make([]string, len(s))
-	for i := range s {
-		strs[i] = strconv.FormatFloat(float64(s[i]), 'f', -1, 64)
-	}
-	return strs
+	return slice.FormatFloats(s, 'f', -1)
 }
@@ -2,6 +2,7 @@ package str
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -49,6 +50,18 @@ func BenchmarkStrConcat(b *testing.B) {
 	}
 }
 
+func TestConcatFunc(t *testing.T) {
+	got := ConcatFunc([]string{"a", "", "b", "c"}, ",", func(s string) (string, bool) {
+		return strings.ToUpper(s), s != ""
+	})
+	if got != "A,B,C" {
+		t.Errorf("ConcatFunc = %q", got)
+	}
+	if got := ConcatFunc(nil, ",", func(s string) (string, bool) { return s, true }); got != "" {
+		t.Errorf("ConcatFunc = %q", got)
+	}
+}
+
 func TestToNumber(t *testing.T) {
 	// Testing parsing of a valid integer string
 	intResult, err := ToNumber[int]("123")
@@ -0,0 +1,70 @@
+package str
+
+import "strings"
+
+// FormatTable renders rows as a bordered text table. Columns are aligned by
+// DisplayWidth, so mixed Chinese and English cells line up in a terminal.
+// Rows may have different lengths, as returned by excel.Read, missing cells are
+// left blank and line breaks inside a cell are shown as spaces. When header is
+// true the first row is separated from the others.
+//
+//	+------+-----+
+//	| 姓名 | age |
+//	+------+-----+
+//	| 张三 | 18  |
+//	+------+-----+
+func FormatTable(rows [][]string, header bool) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	cols := 0
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	if cols == 0 {
+		return ""
+	}
+
+	flatten := strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ")
+	cells := make([][]string, len(rows))
+	widths := make([]int, cols)
+	for i, row := range rows {
+		cells[i] = make([]string, cols)
+		for j, cell := range row {
+			cell = flatten.Replace(cell)
+			cells[i][j] = cell
+			if w := DisplayWidth(cell); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+
+	var sep strings.Builder
+	sep.WriteByte('+')
+	for _, w := range widths {
+		sep.WriteString(strings.Repeat("-", w+2))
+		sep.WriteByte('+')
+	}
+	sep.WriteByte('\n')
+	line := sep.String()
+
+	var b strings.Builder
+	b.WriteString(line)
+	for i, row := range cells {
+		b.WriteByte('|')
+		for j, cell := range row {
+			b.WriteByte(' ')
+			b.WriteString(PadRight(cell, widths[j], ' '))
+			b.WriteString(" |")
+		}
+		b.WriteByte('\n')
+		if i == 0 && header && len(cells) > 1 {
+			b.WriteString(line)
+		}
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,28 @@
+package str
+
+import "testing"
+
+func TestFormatTable(t *testing.T) {
+	rows := [][]string{
+		{"姓名", "age"},
+		{"张三", "18", "extra"},
+		{"Tom"},
+	}
+	want := "" +
+		"+------+-----+-------+\n" +
+		"| 姓名 | age |       |\n" +
+		"+------+-----+-------+\n" +
+		"| 张三 | 18  | extra |\n" +
+		"| Tom  |     |       |\n" +
+		"+------+-----+-------+\n"
+	if got := FormatTable(rows, true); got != want {
+		t.Errorf("FormatTable =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := FormatTable(nil, true); got != "" {
+		t.Errorf("Expected empty table, but got %q", got)
+	}
+	if got := FormatTable([][]string{{"a\nb"}}, false); got != "+-----+\n| a b |\n+-----+\n" {
+		t.Errorf("FormatTable = %q", got)
+	}
+}
@@ -0,0 +1,41 @@
+package str
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Template replaces {name} placeholders in tpl with the values in data,
+// formatted with fmt.Sprint. Placeholders without a value are kept as they are,
+// "{{" and "}}" write a literal brace.
+//
+// Template("{user} has {n} tasks", map[string]any{"user": "hy", "n": 3}) => "hy has 3 tasks"
+func Template(tpl string, data map[string]any) string {
+	var b strings.Builder
+	b.Grow(len(tpl))
+	for i := 0; i < len(tpl); i++ {
+		c := tpl[i]
+		switch {
+		case c == '{' && i+1 < len(tpl) && tpl[i+1] == '{',
+			c == '}' && i+1 < len(tpl) && tpl[i+1] == '}':
+			b.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(tpl[i+1:], '}')
+			if end < 0 {
+				b.WriteString(tpl[i:])
+				return b.String()
+			}
+			name := tpl[i+1 : i+1+end]
+			if v, ok := data[strings.TrimSpace(name)]; ok {
+				b.WriteString(fmt.Sprint(v))
+			} else {
+				b.WriteString(tpl[i : i+end+2])
+			}
+			i += end + 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
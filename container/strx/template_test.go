@@ -0,0 +1,20 @@
+package str
+
+import "testing"
+
+func TestTemplate(t *testing.T) {
+	data := map[string]any{"user": "hy", "n": 3}
+	tests := map[string]string{
+		"{user} has {n} tasks": "hy has 3 tasks",
+		"{ user }":             "hy",
+		"{missing} {user}":     "{missing} hy",
+		"{{user}} {n}":         "{user} 3",
+		"open {user":           "open {user",
+		"":                     "",
+	}
+	for tpl, want := range tests {
+		if got := Template(tpl, data); got != want {
+			t.Errorf("Template(%q) = %q, want %q", tpl, got, want)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package str
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hy-shine/gotiny/container"
+)
+
+const (
+	zeroWidthJoiner   = '\u200d'
+	emojiPresentation = '\ufe0f'
+)
+
+// RuneWidth returns the number of terminal columns r occupies:
+// 2 for East Asian wide and full-width runes, 0 for combining marks
+// and other zero-width runes, 1 otherwise.
+func RuneWidth(r rune) int {
+	switch {
+	case r == 0 || container.IsZeroWidth(r):
+		return 0
+	case container.IsFullWidth(r):
+		return 2
+	}
+	return 1
+}
+
+func isVariationSelector(r rune) bool {
+	return r >= '\ufe00' && r <= '\ufe0f' || r >= 0xe0100 && r <= 0xe01ef
+}
+
+func isEmojiModifier(r rune) bool {
+	return r >= 0x1f3fb && r <= 0x1f3ff
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// Graphemes splits s into user-perceived characters: a base rune followed by
+// its combining marks, variation selectors and emoji modifiers, emoji joined
+// with zero-width joiners, and regional indicator pairs (flags).
+// It covers the common cases of the Unicode segmentation rules, not all of them.
+func Graphemes(s string) []string {
+	clusters := make([]string, 0, len(s))
+	start := 0
+	joined := false
+	var prev rune
+	for i, r := range s {
+		if i > start {
+			extend := joined ||
+				r == zeroWidthJoiner ||
+				unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Mc, r) ||
+				isVariationSelector(r) || isEmojiModifier(r) ||
+				isRegionalIndicator(r) && isRegionalIndicator(prev) && utf8.RuneCountInString(s[start:i]) == 1
+			if !extend {
+				clusters = append(clusters, s[start:i])
+				start = i
+			}
+		}
+		joined = r == zeroWidthJoiner
+		prev = r
+	}
+	if start < len(s) {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
+
+// graphemeWidth returns the columns of one cluster, the width of its base rune
+// widened to 2 by an emoji presentation selector or a flag.
+func graphemeWidth(g string) int {
+	base, size := utf8.DecodeRuneInString(g)
+	w := RuneWidth(base)
+	if w == 1 && (strings.ContainsRune(g[size:], emojiPresentation) || isRegionalIndicator(base)) {
+		w = 2
+	}
+	return w
+}
+
+// DisplayWidth returns the number of terminal columns s occupies,
+// so "Go语言" is 6 columns wide while it has 4 runes.
+func DisplayWidth(s string) int {
+	total := 0
+	for _, g := range Graphemes(s) {
+		total += graphemeWidth(g)
+	}
+	return total
+}
+
+// PadLeft pads s on the left with pad until it is w columns wide.
+func PadLeft(s string, w int, pad rune) string {
+	return padding(w-DisplayWidth(s), pad) + s
+}
+
+// PadRight pads s on the right with pad until it is w columns wide.
+func PadRight(s string, w int, pad rune) string {
+	return s + padding(w-DisplayWidth(s), pad)
+}
+
+// Center pads s on both sides with pad until it is w columns wide,
+// the extra column goes to the right if the padding is uneven.
+func Center(s string, w int, pad rune) string {
+	n := w - DisplayWidth(s)
+	if n <= 0 {
+		return s
+	}
+	return padding(n/2, pad) + s + padding(n-n/2, pad)
+}
+
+// padding returns n columns of pad, completed with spaces if pad is wide.
+func padding(n int, pad rune) string {
+	if n <= 0 {
+		return ""
+	}
+	pw := RuneWidth(pad)
+	if pw < 1 {
+		pad, pw = ' ', 1
+	}
+	return strings.Repeat(string(pad), n/pw) + strings.Repeat(" ", n%pw)
+}
+
+// Truncate shortens s to at most w columns, replacing the cut part with ellipsis.
+// It never splits a grapheme, so the result may be a column narrower than w.
+//
+// Truncate("Hello, 世界", 8, "...") => "Hello..."
+//
+// A w of 0 or less gives "".
+func Truncate(s string, w int, ellipsis string) string {
+	if w <= 0 {
+		return ""
+	}
+	if DisplayWidth(s) <= w {
+		return s
+	}
+
+	limit := w - DisplayWidth(ellipsis)
+	if limit < 0 {
+		return Truncate(ellipsis, w, "")
+	}
+	var b strings.Builder
+	used := 0
+	for _, g := range Graphemes(s) {
+		gw := graphemeWidth(g)
+		if used+gw > limit {
+			break
+		}
+		b.WriteString(g)
+		used += gw
+	}
+	b.WriteString(ellipsis)
+	return b.String()
+}
+
+type wrapSegment struct {
+	text        string
+	width       int
+	spaceBefore bool
+}
+
+// segments splits a line into words. Wide graphemes are words of their own,
+// since CJK text may break between any two characters.
+func segments(line string) []wrapSegment {
+	var (
+		segs  []wrapSegment
+		word  strings.Builder
+		wordW int
+		space bool
+	)
+	flush := func() {
+		if word.Len() > 0 {
+			segs = append(segs, wrapSegment{text: word.String(), width: wordW, spaceBefore: space})
+			word.Reset()
+			wordW, space = 0, false
+		}
+	}
+
+	for _, g := range Graphemes(line) {
+		r, _ := utf8.DecodeRuneInString(g)
+		gw := graphemeWidth(g)
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+			space = len(segs) > 0
+		case gw > 1:
+			flush()
+			segs = append(segs, wrapSegment{text: g, width: gw, spaceBefore: space})
+			space = false
+		default:
+			word.WriteString(g)
+			wordW += gw
+		}
+	}
+	flush()
+	return segs
+}
+
+// Wrap breaks s into lines of at most w columns. Lines break at spaces or
+// between wide characters, a word longer than w is split. Existing line breaks
+// are kept and runs of spaces within a line collapse to one.
+func Wrap(s string, w int) string {
+	if w < 1 {
+		w = 1
+	}
+
+	var out strings.Builder
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			out.WriteByte('\n')
+		}
+
+		lineW := 0
+		for _, seg := range segments(line) {
+			need := seg.width
+			if lineW > 0 && seg.spaceBefore {
+				need++
+			}
+			if lineW > 0 && lineW+need > w {
+				out.WriteByte('\n')
+				lineW, need = 0, seg.width
+			}
+			if lineW > 0 && seg.spaceBefore {
+				out.WriteByte(' ')
+			}
+			if seg.width <= w-lineW {
+				out.WriteString(seg.text)
+				lineW += seg.width
+				continue
+			}
+
+			// the word does not fit on an empty line, split it
+			for _, g := range Graphemes(seg.text) {
+				gw := graphemeWidth(g)
+				if lineW > 0 && lineW+gw > w {
+					out.WriteByte('\n')
+					lineW = 0
+				}
+				out.WriteString(g)
+				lineW += gw
+			}
+		}
+	}
+	return out.String()
+}
+
+// Indent adds prefix to the start of every non-blank line of s.
+func Indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		if strings.TrimSpace(lines[i]) != "" {
+			lines[i] = prefix + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Dedent removes the longest common leading whitespace of the non-blank
+// lines of s, so an indented raw string literal can be written in place.
+// Blank lines are emptied.
+func Dedent(s string) string {
+	lines := strings.Split(s, "\n")
+	margin := ""
+	first := true
+	for _, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		indent := line[:len(line)-len(trimmed)]
+		if first {
+			margin, first = indent, false
+			continue
+		}
+		for !strings.HasPrefix(indent, margin) {
+			margin = margin[:len(margin)-1]
+		}
+	}
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = strings.TrimPrefix(line, margin)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,126 @@
+package str
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{input: "", want: 0},
+		{input: "hello", want: 5},
+		{input: "Go语言", want: 6},
+		{input: "ｇｏ", want: 4},
+		{input: "é", want: 1},
+		{input: "👍", want: 2},
+		{input: "👍🏽", want: 2},
+		{input: "❤️", want: 2},
+		{input: "👨‍👩‍👧", want: 2},
+		{input: "🇨🇳", want: 2},
+		{input: "ｶﾀｶﾅ", want: 4},
+		{input: "co\u00adop", want: 5},
+	}
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.input); got != tt.want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{input: "", want: []string{}},
+		{input: "ab", want: []string{"a", "b"}},
+		{input: "éx", want: []string{"é", "x"}},
+		{input: "中👍🏽", want: []string{"中", "👍🏽"}},
+		{input: "👨‍👩‍👧!", want: []string{"👨‍👩‍👧", "!"}},
+		{input: "🇨🇳🇺🇸", want: []string{"🇨🇳", "🇺🇸"}},
+	}
+	for _, tt := range tests {
+		if got := Graphemes(tt.input); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Graphemes(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	if got := PadLeft("中文", 6, ' '); got != "  中文" {
+		t.Errorf("PadLeft = %q", got)
+	}
+	if got := PadRight("ab", 5, '.'); got != "ab..." {
+		t.Errorf("PadRight = %q", got)
+	}
+	if got := PadRight("abcdef", 3, ' '); got != "abcdef" {
+		t.Errorf("PadRight should not cut, got %q", got)
+	}
+	if got := Center("中", 7, '-'); got != "--中---" {
+		t.Errorf("Center = %q", got)
+	}
+	if got := PadLeft("a", 4, '　'); got != "　 a" {
+		t.Errorf("PadLeft with wide pad = %q", got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input    string
+		width    int
+		ellipsis string
+		want     string
+	}{
+		{input: "hello", width: 10, ellipsis: "...", want: "hello"},
+		{input: "Hello, 世界", width: 8, ellipsis: "...", want: "Hello..."},
+		{input: "中文中文", width: 5, ellipsis: "…", want: "中文…"},
+		{input: "中文中文", width: 6, ellipsis: "…", want: "中文…"},
+		{input: "ééé", width: 2, ellipsis: ".", want: "é."},
+		{input: "abcdef", width: 2, ellipsis: "...", want: ".."},
+		{input: "hello", width: 0, ellipsis: "...", want: ""},
+		{input: "hello", width: -1, ellipsis: "...", want: ""},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.input, tt.width, tt.ellipsis); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		input string
+		width int
+		want  string
+	}{
+		{input: "the quick brown fox", width: 10, want: "the quick\nbrown fox"},
+		{input: "a  b", width: 10, want: "a b"},
+		{input: "abcdefghij", width: 4, want: "abcd\nefgh\nij"},
+		{input: "中文字符换行", width: 5, want: "中文\n字符\n换行"},
+		{input: "go 语言", width: 4, want: "go\n语言"},
+		{input: "line one\nline two", width: 20, want: "line one\nline two"},
+	}
+	for _, tt := range tests {
+		if got := Wrap(tt.input, tt.width); got != tt.want {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestIndentDedent(t *testing.T) {
+	if got := Indent("a\n\nb", "  "); got != "  a\n\n  b" {
+		t.Errorf("Indent = %q", got)
+	}
+
+	input := "\n    func main() {\n        run()\n    }\n  \n"
+	want := "\nfunc main() {\n    run()\n}\n\n"
+	if got := Dedent(input); got != want {
+		t.Errorf("Dedent = %q, want %q", got, want)
+	}
+	if got := Dedent("  a\n\tb"); got != "  a\n\tb" {
+		t.Errorf("Dedent with mixed indent = %q", got)
+	}
+}
@@ -0,0 +1,93 @@
+package container
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// IsAlnum reports whether s is non-empty and made only of ASCII letters and digits.
+func IsAlnum(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !IsValidLetter(s[i]) && !IsNumber(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAlpha reports whether s is non-empty and made only of ASCII letters.
+func IsAlpha(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !IsValidLetter(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsDigits reports whether s is non-empty and made only of ASCII digits.
+func IsDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !IsNumber(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsHex reports whether s is non-empty and made only of hexadecimal digits,
+// without a 0x prefix.
+func IsHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !IsNumber(c) && !(c >= 'a' && c <= 'f') && !(c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// IsASCII reports whether s contains only ASCII, true for an empty string.
+func IsASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPrintable reports whether every rune of s is printable as defined by
+// unicode.IsPrint, true for an empty string. Invalid UTF-8 is not printable,
+// the replacement character U+FFFD decoders put in its place is.
+func IsPrintable(s string) bool {
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			// ASCII fast path: space to tilde
+			if c < ' ' || c > '~' {
+				return false
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if (r == utf8.RuneError && size == 1) || !unicode.IsPrint(r) {
+			return false
+		}
+		i += size
+	}
+	return true
+}
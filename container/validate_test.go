@@ -0,0 +1,43 @@
+package container
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStringValidators(t *testing.T) {
+	cases := []struct {
+		name string
+		f    func(string) bool
+		yes  []string
+		no   []string
+	}{
+		{name: "IsAlnum", f: IsAlnum, yes: []string{"abc123", "A", "9"}, no: []string{"", "a b", "a_1", "中1", "１"}},
+		{name: "IsAlpha", f: IsAlpha, yes: []string{"abc", "XyZ"}, no: []string{"", "a1", "é"}},
+		{name: "IsDigits", f: IsDigits, yes: []string{"0", "0123"}, no: []string{"", "-1", "1.0", "１"}},
+		{name: "IsHex", f: IsHex, yes: []string{"deadBEEF", "09af"}, no: []string{"", "0x1f", "fg"}},
+		{name: "IsASCII", f: IsASCII, yes: []string{"", "hello\n", "~!@"}, no: []string{"héllo", "中", "\xff"}},
+		{name: "IsPrintable", f: IsPrintable, yes: []string{"", "hello world", "中文，。", "😀", "\ufffd"}, no: []string{"a\nb", "\t", "\x00", "\xff", "a\u200bb", "\xe4\xb8"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, s := range c.yes {
+				if !c.f(s) {
+					t.Errorf("%s(%q) = false, want true", c.name, s)
+				}
+			}
+			for _, s := range c.no {
+				if c.f(s) {
+					t.Errorf("%s(%q) = true, want false", c.name, s)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkIsAlnum(b *testing.B) {
+	s := strings.Repeat("abcXYZ123", 16)
+	for i := 0; i < b.N; i++ {
+		_ = IsAlnum(s)
+	}
+}
@@ -6,6 +6,7 @@ require (
 	gitee.com/chunanyong/zorm v1.5.4
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/exp v0.0.0-20221111204811-129d8d6c17ab
+	golang.org/x/text v0.14.0
 	gorm.io/gorm v1.25.7
 )
 
@@ -40,7 +41,6 @@ require (
 	golang.org/x/crypto v0.19.0 // indirect
 	golang.org/x/net v0.21.0 // indirect
 	golang.org/x/sys v0.17.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
 	google.golang.org/protobuf v1.30.0 // indirect
 )
 
@@ -1,8 +1,45 @@
 package id
 
-import "github.com/google/uuid"
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // NewUuid returns an uuid string.
 func NewUuid() string {
 	return uuid.New().String()
 }
+
+// NewUuidFromReader returns a version 4 uuid string built from the bytes
+// of r, so a seeded reader gives reproducible uuids in tests.
+func NewUuidFromReader(r io.Reader) (string, error) {
+	u, err := uuid.NewRandomFromReader(r)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// NewUuidV7 returns a version 7 uuid string. Its leading 48 bits are the
+// Unix time in milliseconds, so v7 uuids sort by creation time and keep
+// B-tree primary keys compact where v4 ones fragment them.
+func NewUuidV7() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// UuidTime returns the creation time of a version 1, 6 or 7 uuid string.
+func UuidTime(s string) (time.Time, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: uuid %q", ErrInvalidID, s)
+	}
+	switch u.Version() {
+	case 1, 6, 7:
+		sec, nsec := u.Time().UnixTime()
+		return time.Unix(sec, nsec), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: uuid version %d has no time", ErrInvalidID, u.Version())
+}
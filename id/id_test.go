@@ -0,0 +1,49 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUuid(t *testing.T) {
+	if a, b := NewUuid(), NewUuid(); len(a) != 36 || a == b {
+		t.Errorf("NewUuid() = %q, %q", a, b)
+	}
+	seed := bytes.Repeat([]byte{7}, 16)
+	a, err := NewUuidFromReader(bytes.NewReader(seed))
+	b, _ := NewUuidFromReader(bytes.NewReader(seed))
+	if err != nil || a != b {
+		t.Errorf("NewUuidFromReader = %q, %q, %v", a, b, err)
+	}
+	if _, err := NewUuidFromReader(bytes.NewReader(nil)); err == nil {
+		t.Error("an empty reader should fail")
+	}
+}
+
+func TestUuidV7(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	prev := NewUuidV7()
+	for i := 0; i < 100; i++ {
+		u := NewUuidV7()
+		if u <= prev {
+			t.Fatalf("%s is not after %s", u, prev)
+		}
+		prev = u
+	}
+	ts, err := UuidTime(prev)
+	if err != nil || ts.Before(before) || ts.After(time.Now()) {
+		t.Errorf("UuidTime(%s) = %v, %v", prev, ts, err)
+	}
+	if prev[14] != '7' {
+		t.Errorf("%s is not a version 7 uuid", prev)
+	}
+
+	if _, err := UuidTime(NewUuid()); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("UuidTime(v4) error = %v", err)
+	}
+	if _, err := UuidTime("nope"); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("UuidTime(nope) error = %v", err)
+	}
+}
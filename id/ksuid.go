@@ -0,0 +1,120 @@
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// KSUIDEpoch is the time of KSUID timestamp 0, 2014-05-13 16:53:20 UTC.
+const KSUIDEpoch = 1400000000
+
+// KSUID is a 160-bit id made of a 32-bit second timestamp and a 128-bit
+// random payload, compatible with segmentio/ksuid. Its 27-character
+// base62 form sorts by time.
+type KSUID [20]byte
+
+const (
+	ksuidAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	ksuidLen      = 27
+)
+
+// NewKSUID returns a KSUID for the current time with a crypto/rand payload.
+func NewKSUID() KSUID {
+	k, err := NewKSUIDFrom(time.Now(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// NewKSUIDFrom returns a KSUID for t with a payload read from r.
+func NewKSUIDFrom(t time.Time, r io.Reader) (KSUID, error) {
+	var k KSUID
+	ts := t.Unix() - KSUIDEpoch
+	if ts < 0 || ts >= 1<<32 {
+		return k, fmt.Errorf("ksuid: time %v out of range", t)
+	}
+	binary.BigEndian.PutUint32(k[:4], uint32(ts))
+	if _, err := io.ReadFull(r, k[4:]); err != nil {
+		return KSUID{}, err
+	}
+	return k, nil
+}
+
+// Time returns the timestamp of k.
+func (k KSUID) Time() time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint32(k[:4]))+KSUIDEpoch, 0)
+}
+
+// Payload returns the 16 random bytes of k.
+func (k KSUID) Payload() []byte {
+	return append([]byte(nil), k[4:]...)
+}
+
+// String returns the 27-character base62 form of k.
+func (k KSUID) String() string {
+	// long division of the 160-bit number by 62, as five 32-bit words
+	var words [5]uint32
+	for i := range words {
+		words[i] = binary.BigEndian.Uint32(k[i*4:])
+	}
+	var dst [ksuidLen]byte
+	for i := ksuidLen - 1; i >= 0; i-- {
+		var rem uint64
+		for j := range words {
+			cur := rem<<32 | uint64(words[j])
+			words[j] = uint32(cur / 62)
+			rem = cur % 62
+		}
+		dst[i] = ksuidAlphabet[rem]
+	}
+	return string(dst[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k KSUID) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *KSUID) UnmarshalText(text []byte) error {
+	v, err := ParseKSUID(string(text))
+	if err != nil {
+		return err
+	}
+	*k = v
+	return nil
+}
+
+// ParseKSUID parses the base62 form of a KSUID.
+func ParseKSUID(s string) (KSUID, error) {
+	if len(s) != ksuidLen {
+		return KSUID{}, fmt.Errorf("%w: ksuid %q", ErrInvalidID, s)
+	}
+	var words [5]uint32
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(ksuidAlphabet, s[i])
+		if d < 0 {
+			return KSUID{}, fmt.Errorf("%w: ksuid %q", ErrInvalidID, s)
+		}
+		// words = words*62 + d
+		carry := uint64(d)
+		for j := len(words) - 1; j >= 0; j-- {
+			cur := uint64(words[j])*62 + carry
+			words[j] = uint32(cur)
+			carry = cur >> 32
+		}
+		if carry != 0 {
+			return KSUID{}, fmt.Errorf("%w: ksuid %q overflows 160 bits", ErrInvalidID, s)
+		}
+	}
+	var k KSUID
+	for i, w := range words {
+		binary.BigEndian.PutUint32(k[i*4:], w)
+	}
+	return k, nil
+}
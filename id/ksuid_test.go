@@ -0,0 +1,57 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKSUID(t *testing.T) {
+	// the example of segmentio/ksuid
+	k, err := ParseKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Time().Unix() != 1507608047 {
+		t.Errorf("Time() = %v", k.Time().UTC())
+	}
+	if got := k.Payload(); !bytes.Equal(got, []byte{0xb5, 0xa1, 0xcd, 0x34, 0xb5, 0xf9, 0x9d, 0x11, 0x54, 0xfb, 0x68, 0x53, 0x34, 0x5c, 0x97, 0x35}) {
+		t.Errorf("Payload() = %x", got)
+	}
+	if k.String() != "0ujtsYcgvSTl8PAuAdqWYSMnLOv" {
+		t.Errorf("String() = %s", k)
+	}
+
+	var max KSUID
+	for i := range max {
+		max[i] = 0xff
+	}
+	if max.String() != "aWgEPTl1tmebfsQzFP4bxwgy80V" {
+		t.Errorf("max KSUID = %s", max)
+	}
+	if (KSUID{}).String() != "000000000000000000000000000" {
+		t.Errorf("zero KSUID = %s", KSUID{})
+	}
+
+	a := NewKSUID()
+	if time.Since(a.Time()) > 2*time.Second {
+		t.Errorf("NewKSUID time = %v", a.Time())
+	}
+	if back, err := ParseKSUID(a.String()); err != nil || back != a {
+		t.Errorf("round trip = %s, %v", back, err)
+	}
+	old, _ := NewKSUIDFrom(time.Now().Add(-time.Hour), bytes.NewReader(make([]byte, 16)))
+	if old.String() >= a.String() {
+		t.Errorf("%s does not sort before %s", old, a)
+	}
+
+	for _, s := range []string{"", "0ujtsYcgvSTl8PAuAdqWYSMnLO", "0ujtsYcgvSTl8PAuAdqWYSMnLO-", "aWgEPTl1tmebfsQzFP4bxwgy80W"} {
+		if _, err := ParseKSUID(s); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("ParseKSUID(%q) error = %v", s, err)
+		}
+	}
+	if _, err := NewKSUIDFrom(time.Unix(0, 0), bytes.NewReader(make([]byte, 16))); err == nil {
+		t.Error("a time before the KSUID epoch should fail")
+	}
+}
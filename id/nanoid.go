@@ -0,0 +1,63 @@
+package id
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hy-shine/gotiny/rand"
+)
+
+// NanoIDAlphabet is the URL-safe alphabet of NanoID.
+const NanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// NanoIDSize is the default NanoID length, 21 characters hold about as
+// much randomness as a v4 uuid.
+const NanoIDSize = 21
+
+// NewNanoID returns a random id of NanoIDSize characters of
+// NanoIDAlphabet, drawn from crypto/rand.
+func NewNanoID() string {
+	return rand.RandString(NanoIDSize, NanoIDAlphabet)
+}
+
+// NewNanoIDWith returns a random id of size characters of alphabet,
+// such as 12 digits for an order number. The alphabet needs 2 to 128
+// distinct ASCII characters. A NanoID has no timestamp or node to
+// decompose, ValidNanoID checks one.
+func NewNanoIDWith(alphabet string, size int) (string, error) {
+	if err := checkAlphabet(alphabet); err != nil {
+		return "", err
+	}
+	if size <= 0 {
+		return "", fmt.Errorf("nanoid: size %d is not positive", size)
+	}
+	return rand.RandString(size, alphabet), nil
+}
+
+func checkAlphabet(alphabet string) error {
+	if len(alphabet) < 2 || len(alphabet) > 128 {
+		return fmt.Errorf("nanoid: alphabet needs 2 to 128 characters, got %d", len(alphabet))
+	}
+	var seen [256]bool
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c >= 0x80 || seen[c] {
+			return fmt.Errorf("nanoid: alphabet has a duplicate or non-ASCII character %q", c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// ValidNanoID reports whether s has size characters, all from alphabet.
+func ValidNanoID(s, alphabet string, size int) bool {
+	if len(s) != size {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(alphabet, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
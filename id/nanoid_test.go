@@ -0,0 +1,30 @@
+package id
+
+import "testing"
+
+func TestNanoID(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id := NewNanoID()
+		if !ValidNanoID(id, NanoIDAlphabet, NanoIDSize) || seen[id] {
+			t.Fatalf("NewNanoID() = %q", id)
+		}
+		seen[id] = true
+	}
+
+	id, err := NewNanoIDWith("0123456789", 12)
+	if err != nil || !ValidNanoID(id, "0123456789", 12) {
+		t.Errorf("NewNanoIDWith(digits, 12) = %q, %v", id, err)
+	}
+	for _, alphabet := range []string{"", "a", "abca", "αβγ"} {
+		if _, err := NewNanoIDWith(alphabet, 10); err == nil {
+			t.Errorf("NewNanoIDWith(%q) should fail", alphabet)
+		}
+	}
+	if _, err := NewNanoIDWith("ab", 0); err == nil {
+		t.Error("NewNanoIDWith with size 0 should fail")
+	}
+	if ValidNanoID("abc", "ab", 3) {
+		t.Error("ValidNanoID accepted a foreign character")
+	}
+}
@@ -0,0 +1,144 @@
+package id
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SegmentConfig configures NewSegment. The zero value of a field selects
+// its default.
+type SegmentConfig struct {
+	// Client defaults to redis.GetInstance().
+	Client RedisClient
+	// Key is the Redis counter, one per id sequence. It is required.
+	Key string
+	// Step is the size of a range fetched with one INCRBY, 1000 by
+	// default. All allocators of a Key must use the same Step.
+	Step int64
+	// Threshold is the share of the current range left when the next one
+	// is prefetched, 0.2 by default.
+	Threshold float64
+	// Timeout bounds one INCRBY, 3s by default.
+	Timeout time.Duration
+}
+
+type segmentRange struct {
+	next, max int64
+}
+
+// Segment hands out ids from ranges reserved in Redis, as in Meituan's
+// Leaf segment mode. Each INCRBY reserves Step ids, and the next range is
+// fetched in the background while the current one is used, so Next
+// rarely waits for Redis. Ids of one allocator increase, ids across
+// allocators of a Key are unique but interleave. Ids left in a range are
+// skipped when the process exits. It is safe for concurrent use.
+type Segment struct {
+	cfg SegmentConfig
+
+	mu      sync.Mutex
+	cur     segmentRange
+	buf     *segmentRange
+	loading chan struct{} // closed when the running load ends, nil if none runs
+	loadErr error
+}
+
+// NewSegment returns a Segment for cfg after fetching its first range. It
+// returns ErrNoRedisClient if there is no client to use.
+func NewSegment(ctx context.Context, cfg SegmentConfig) (*Segment, error) {
+	if cfg.Key == "" {
+		return nil, fmt.Errorf("segment: key is empty")
+	}
+	client, err := redisClient(cfg.Client)
+	if err != nil {
+		return nil, fmt.Errorf("segment %s: %w", cfg.Key, err)
+	}
+	cfg.Client = client
+	if cfg.Step <= 0 {
+		cfg.Step = 1000
+	}
+	if cfg.Threshold <= 0 || cfg.Threshold >= 1 {
+		cfg.Threshold = 0.2
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 3 * time.Second
+	}
+	s := &Segment{cfg: cfg}
+	r, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.cur = r
+	return s, nil
+}
+
+// Next returns a new id. It waits for Redis, or until ctx is done, only
+// when the current range ran out before the next one arrived.
+func (s *Segment) Next(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	for {
+		if s.cur.next <= s.cur.max {
+			v := s.cur.next
+			s.cur.next++
+			left := s.cur.max - s.cur.next + 1
+			if s.buf == nil && s.loading == nil && float64(left) < s.cfg.Threshold*float64(s.cfg.Step) {
+				s.load()
+			}
+			s.mu.Unlock()
+			return v, nil
+		}
+		if s.buf != nil {
+			s.cur, s.buf = *s.buf, nil
+			continue
+		}
+		if s.loading == nil {
+			s.load()
+		}
+		loading := s.loading
+		s.mu.Unlock()
+
+		select {
+		case <-loading:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+
+		s.mu.Lock()
+		if s.buf == nil && s.loadErr != nil {
+			err := s.loadErr
+			s.mu.Unlock()
+			return 0, err
+		}
+	}
+}
+
+// load fetches the next range in the background, s.mu must be held.
+func (s *Segment) load() {
+	done := make(chan struct{})
+	s.loading = done
+	s.loadErr = nil
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Timeout)
+		r, err := s.fetch(ctx)
+		cancel()
+
+		s.mu.Lock()
+		if err != nil {
+			s.loadErr = err
+		} else {
+			s.buf = &r
+		}
+		s.loading = nil
+		close(done)
+		s.mu.Unlock()
+	}()
+}
+
+func (s *Segment) fetch(ctx context.Context) (segmentRange, error) {
+	max, err := s.cfg.Client.IncrBy(ctx, s.cfg.Key, s.cfg.Step).Result()
+	if err != nil {
+		return segmentRange{}, fmt.Errorf("segment %s: %w", s.cfg.Key, err)
+	}
+	return segmentRange{next: max - s.cfg.Step + 1, max: max}, nil
+}
@@ -0,0 +1,167 @@
+package id
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSegment(t *testing.T) {
+	r := newFakeRedis()
+	ctx := context.Background()
+	a, err := NewSegment(ctx, SegmentConfig{Client: r, Key: "order", Step: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewSegment(ctx, SegmentConfig{Client: r, Key: "order", Step: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[int64]bool)
+		wg   sync.WaitGroup
+	)
+	for g := 0; g < 8; g++ {
+		s := a
+		if g%2 == 1 {
+			s = b
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				id, err := s.Next(ctx)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("duplicate id %d", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != 8000 {
+		t.Fatalf("got %d ids, want 8000", len(seen))
+	}
+}
+
+func TestSegmentOrder(t *testing.T) {
+	r := newFakeRedis()
+	ctx := context.Background()
+	s, err := NewSegment(ctx, SegmentConfig{Client: r, Key: "k", Step: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for want := int64(1); want <= 35; want++ {
+		got, err := s.Next(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	}
+}
+
+func TestSegmentPrefetch(t *testing.T) {
+	r := newFakeRedis()
+	ctx := context.Background()
+	s, err := NewSegment(ctx, SegmentConfig{Client: r, Key: "k", Step: 10, Threshold: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 6; i++ {
+		if _, err := s.Next(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 4 of 10 left, the second range is fetched without waiting for Next
+	deadline := time.Now().Add(time.Second)
+	for {
+		r.mu.Lock()
+		n := r.incrs
+		r.mu.Unlock()
+		if n == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d INCRBY, want 2", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSegmentWait(t *testing.T) {
+	r := newFakeRedis()
+	ctx := context.Background()
+	s, err := NewSegment(ctx, SegmentConfig{Client: r, Key: "k", Step: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delay := make(chan struct{})
+	r.mu.Lock()
+	r.delay = delay
+	r.mu.Unlock()
+	for i := 0; i < 2; i++ {
+		if _, err := s.Next(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the range ran out while the next one is still loading
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := s.Next(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want DeadlineExceeded", err)
+	}
+	close(delay)
+	got, err := s.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 3 {
+		t.Fatalf("got %d, want 3", got)
+	}
+}
+
+func TestSegmentError(t *testing.T) {
+	r := newFakeRedis()
+	ctx := context.Background()
+	if _, err := NewSegment(ctx, SegmentConfig{Client: r}); err == nil {
+		t.Fatal("segment without a key")
+	}
+	s, err := NewSegment(ctx, SegmentConfig{Client: r, Key: "k", Step: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	down := errors.New("connection refused")
+	r.setErr(down)
+	for i := 0; i < 2; i++ {
+		if _, err := s.Next(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Next(ctx); !errors.Is(err, down) {
+		t.Fatalf("got %v, want %v", err, down)
+	}
+
+	// the next call retries once Redis is back
+	r.setErr(nil)
+	got, err := s.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 3 {
+		t.Fatalf("got %d, want 3", got)
+	}
+}
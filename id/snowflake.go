@@ -0,0 +1,153 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrClockBackwards is returned when the clock moved back further than
+	// a Snowflake tolerates.
+	ErrClockBackwards = errors.New("clock moved backwards")
+	// ErrInvalidID is returned when parsing a malformed id.
+	ErrInvalidID = errors.New("invalid id")
+)
+
+// DefaultEpoch is the Snowflake epoch when none is configured.
+var DefaultEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SnowflakeConfig configures a Snowflake. The zero value of a field
+// selects its default.
+type SnowflakeConfig struct {
+	// Epoch is the time of timestamp 0, DefaultEpoch by default. A later
+	// epoch leaves more years before the timestamp bits run out.
+	Epoch time.Time
+	// NodeBits and SequenceBits default to 10 and 12, as in Twitter's
+	// layout. Together they take at most 22 of the 63 bits, the rest is
+	// the millisecond timestamp.
+	NodeBits     uint8
+	SequenceBits uint8
+	// Node identifies the generator, it must fit NodeBits. Two live
+	// generators with the same node produce duplicates.
+	Node int64
+	// MaxBackwards is how long Next waits for the clock to catch up when
+	// it moved back, 10ms by default. Beyond it Next fails with
+	// ErrClockBackwards.
+	MaxBackwards time.Duration
+	// Clock returns the current time, time.Now by default.
+	Clock func() time.Time
+}
+
+// Snowflake generates roughly time-ordered int64 ids made of a
+// millisecond timestamp, a node and a sequence. It is safe for concurrent
+// use.
+type Snowflake struct {
+	cfg       SnowflakeConfig
+	epochMs   int64
+	maxSeq    int64
+	nodeShift uint8
+	timeShift uint8
+
+	mu     sync.Mutex
+	lastMs int64
+	seq    int64
+}
+
+// SnowflakeParts are the fields of a Snowflake id.
+type SnowflakeParts struct {
+	Time     time.Time
+	Node     int64
+	Sequence int64
+}
+
+// NewSnowflake returns a Snowflake for cfg, or an error if its bits or
+// node are out of range.
+func NewSnowflake(cfg SnowflakeConfig) (*Snowflake, error) {
+	if cfg.Epoch.IsZero() {
+		cfg.Epoch = DefaultEpoch
+	}
+	if cfg.NodeBits == 0 {
+		cfg.NodeBits = 10
+	}
+	if cfg.SequenceBits == 0 {
+		cfg.SequenceBits = 12
+	}
+	if cfg.MaxBackwards == 0 {
+		cfg.MaxBackwards = 10 * time.Millisecond
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = time.Now
+	}
+	if int(cfg.NodeBits)+int(cfg.SequenceBits) > 22 {
+		return nil, fmt.Errorf("snowflake: %d node and %d sequence bits leave less than 41 timestamp bits",
+			cfg.NodeBits, cfg.SequenceBits)
+	}
+	if cfg.Node < 0 || cfg.Node >= 1<<cfg.NodeBits {
+		return nil, fmt.Errorf("snowflake: node %d does not fit %d bits", cfg.Node, cfg.NodeBits)
+	}
+	return &Snowflake{
+		cfg:       cfg,
+		epochMs:   cfg.Epoch.UnixMilli(),
+		maxSeq:    1<<cfg.SequenceBits - 1,
+		nodeShift: cfg.SequenceBits,
+		timeShift: cfg.SequenceBits + cfg.NodeBits,
+		lastMs:    -1,
+	}, nil
+}
+
+// Next returns a new id. Ids of one generator strictly increase. It waits
+// for the next millisecond when the sequence of the current one is
+// exhausted, and for the clock to catch up when it moved back by at most
+// MaxBackwards.
+func (s *Snowflake) Next() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now < s.lastMs {
+		back := time.Duration(s.lastMs-now) * time.Millisecond
+		if back > s.cfg.MaxBackwards {
+			return 0, fmt.Errorf("%w by %v", ErrClockBackwards, back)
+		}
+		for now < s.lastMs {
+			time.Sleep(time.Duration(s.lastMs-now) * time.Millisecond)
+			now = s.now()
+		}
+	}
+	if now < 0 {
+		return 0, fmt.Errorf("snowflake: clock is before the epoch %v", s.cfg.Epoch)
+	}
+
+	if now == s.lastMs {
+		s.seq = (s.seq + 1) & s.maxSeq
+		if s.seq == 0 {
+			for now <= s.lastMs {
+				time.Sleep(100 * time.Microsecond)
+				now = s.now()
+			}
+		}
+	} else {
+		s.seq = 0
+	}
+	if now >= 1<<(63-s.timeShift) {
+		return 0, fmt.Errorf("snowflake: timestamp bits exhausted, move the epoch")
+	}
+	s.lastMs = now
+	return now<<s.timeShift | s.cfg.Node<<s.nodeShift | s.seq, nil
+}
+
+func (s *Snowflake) now() int64 {
+	return s.cfg.Clock().UnixMilli() - s.epochMs
+}
+
+// Decompose splits id into its time, node and sequence with the layout
+// of s.
+func (s *Snowflake) Decompose(id int64) SnowflakeParts {
+	return SnowflakeParts{
+		Time:     time.UnixMilli(id>>s.timeShift + s.epochMs),
+		Node:     id >> s.nodeShift & (1<<s.cfg.NodeBits - 1),
+		Sequence: id & s.maxSeq,
+	}
+}
@@ -0,0 +1,136 @@
+package id
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock advances by step on every call.
+type fakeClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.now
+	c.now = c.now.Add(c.step)
+	return t
+}
+
+func (c *fakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+func TestSnowflake(t *testing.T) {
+	s, err := NewSnowflake(SnowflakeConfig{Node: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		mu   sync.Mutex
+		seen = make(map[int64]bool)
+		wg   sync.WaitGroup
+	)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			last := int64(-1)
+			for i := 0; i < 5000; i++ {
+				id, err := s.Next()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if id <= last {
+					t.Errorf("id %d after %d", id, last)
+				}
+				last = id
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("duplicate id %d", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	before := time.Now()
+	id, _ := s.Next()
+	p := s.Decompose(id)
+	if p.Node != 5 || p.Time.Before(before.Add(-time.Millisecond)) || p.Time.After(time.Now()) {
+		t.Errorf("Decompose(%d) = %+v", id, p)
+	}
+}
+
+func TestSnowflakeLayout(t *testing.T) {
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: start}
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s, err := NewSnowflake(SnowflakeConfig{Epoch: epoch, NodeBits: 4, SequenceBits: 2, Node: 9, Clock: clock.Now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the clock stands still: 4 ids fill the sequence, then Next waits
+	// for the next millisecond
+	var ids []int64
+	for i := 0; i < 5; i++ {
+		if i == 4 {
+			clock.step = time.Millisecond
+		}
+		id, err := s.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	for i, id := range ids[:4] {
+		p := s.Decompose(id)
+		if p.Sequence != int64(i) || p.Node != 9 || !p.Time.Equal(start) {
+			t.Errorf("id %d = %+v", i, p)
+		}
+	}
+	if p := s.Decompose(ids[4]); p.Sequence != 0 || !p.Time.After(start) {
+		t.Errorf("id after an exhausted sequence = %+v", p)
+	}
+
+	for _, cfg := range []SnowflakeConfig{
+		{NodeBits: 12, SequenceBits: 12},
+		{NodeBits: 10, SequenceBits: 250},
+		{NodeBits: 255, SequenceBits: 1},
+		{NodeBits: 3, Node: 8},
+		{Node: -1},
+	} {
+		if _, err := NewSnowflake(cfg); err == nil {
+			t.Errorf("NewSnowflake(%+v) should fail", cfg)
+		}
+	}
+}
+
+func TestSnowflakeClockBackwards(t *testing.T) {
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: start, step: time.Millisecond}
+	s, _ := NewSnowflake(SnowflakeConfig{Clock: clock.Now, MaxBackwards: 5 * time.Millisecond})
+	first, _ := s.Next()
+
+	// a small step back is waited out
+	clock.Set(start.Add(-3 * time.Millisecond))
+	id, err := s.Next()
+	if err != nil || id <= first {
+		t.Errorf("after a 3ms step back: %d, %v", id, err)
+	}
+
+	clock.Set(start.Add(-time.Second))
+	if _, err := s.Next(); !errors.Is(err, ErrClockBackwards) {
+		t.Errorf("after a 1s step back: error = %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package id
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrMonotonicOverflow is returned when a millisecond ran out of
+// monotonic ULIDs, after 2^80 of them.
+var ErrMonotonicOverflow = errors.New("ulid: monotonic entropy overflow")
+
+// ULID is a 128-bit id made of a 48-bit millisecond timestamp and 80 bits
+// of entropy. Its 26-character Crockford base32 form sorts by time.
+type ULID [16]byte
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var crockfordValues = func() [256]byte {
+	var v [256]byte
+	for i := range v {
+		v[i] = 0xff
+	}
+	for i := 0; i < len(crockford); i++ {
+		v[crockford[i]] = byte(i)
+		v[crockford[i]|0x20] = byte(i) // lower case
+	}
+	return v
+}()
+
+// ULIDGenerator generates monotonic ULIDs: within one millisecond each
+// ULID is the previous one plus one, so they sort in creation order. It is
+// safe for concurrent use.
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	entropy io.Reader
+	last    ULID
+	lastMs  uint64
+}
+
+// NewULIDGenerator returns a generator reading entropy, crypto/rand if nil.
+func NewULIDGenerator(entropy io.Reader) *ULIDGenerator {
+	if entropy == nil {
+		entropy = rand.Reader
+	}
+	return &ULIDGenerator{entropy: entropy}
+}
+
+// New returns a ULID for t. A t in the same millisecond as the previous
+// call, or before it, continues its sequence.
+func (g *ULIDGenerator) New(t time.Time) (ULID, error) {
+	ms := uint64(t.UnixMilli())
+	if ms >= 1<<48 {
+		return ULID{}, fmt.Errorf("ulid: time %v out of range", t)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var u ULID
+	if ms <= g.lastMs && g.lastMs != 0 {
+		u = g.last
+		// increment the 80-bit entropy
+		i := len(u) - 1
+		for ; i >= 6; i-- {
+			u[i]++
+			if u[i] != 0 {
+				break
+			}
+		}
+		if i < 6 {
+			return ULID{}, ErrMonotonicOverflow
+		}
+	} else {
+		putMillis(u[:6], ms)
+		if _, err := io.ReadFull(g.entropy, u[6:]); err != nil {
+			return ULID{}, err
+		}
+		g.lastMs = ms
+	}
+	g.last = u
+	return u, nil
+}
+
+func putMillis(b []byte, ms uint64) {
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+var defaultULID = NewULIDGenerator(nil)
+
+// NewULID returns a monotonic ULID for the current time.
+func NewULID() ULID {
+	u, err := defaultULID.New(time.Now())
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// Time returns the timestamp of u.
+func (u ULID) Time() time.Time {
+	var ms int64
+	for _, b := range u[:6] {
+		ms = ms<<8 | int64(b)
+	}
+	return time.UnixMilli(ms)
+}
+
+// Entropy returns the 80 random bits of u.
+func (u ULID) Entropy() []byte {
+	return append([]byte(nil), u[6:]...)
+}
+
+// String returns the 26-character base32 form of u.
+func (u ULID) String() string {
+	var dst [26]byte
+	// 128 bits in 26 groups of 5, the first group has 3 bits
+	var acc uint32
+	bits, j := 2, 0 // two leading zero bits pad 128 to 130
+	for _, b := range u {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			dst[j] = crockford[acc>>uint(bits)&31]
+			j++
+		}
+	}
+	return string(dst[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u ULID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *ULID) UnmarshalText(text []byte) error {
+	v, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// ParseULID parses the base32 form of a ULID, in either case.
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != 26 || crockfordValues[s[0]] > 7 {
+		return u, fmt.Errorf("%w: ulid %q", ErrInvalidID, s)
+	}
+	var acc uint32
+	bits, j := 0, 0
+	for i := 0; i < len(s); i++ {
+		v := crockfordValues[s[i]]
+		if v == 0xff {
+			return ULID{}, fmt.Errorf("%w: ulid %q", ErrInvalidID, s)
+		}
+		acc = acc<<5 | uint32(v)
+		bits += 5
+		if i == 0 {
+			bits = 3 // drop the two padding bits
+		}
+		if bits >= 8 {
+			bits -= 8
+			u[j] = byte(acc >> uint(bits))
+			j++
+		}
+	}
+	return u, nil
+}
@@ -0,0 +1,97 @@
+package id
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestULID(t *testing.T) {
+	g := NewULIDGenerator(nil)
+	now := time.Now()
+	prev, err := g.New(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		u, err := g.New(now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.String() <= prev.String() || bytes.Compare(u[:], prev[:]) <= 0 {
+			t.Fatalf("%s is not after %s", u, prev)
+		}
+		prev = u
+	}
+	if !prev.Time().Equal(now.Truncate(time.Millisecond)) {
+		t.Errorf("Time() = %v, want %v", prev.Time(), now)
+	}
+
+	later, _ := g.New(now.Add(time.Millisecond))
+	if later.String() <= prev.String() {
+		t.Errorf("%s is not after %s", later, prev)
+	}
+
+	u := NewULID()
+	if len(u.String()) != 26 || time.Since(u.Time()) > time.Second {
+		t.Errorf("NewULID() = %s at %v", u, u.Time())
+	}
+}
+
+func TestULIDEncoding(t *testing.T) {
+	var max ULID
+	for i := range max {
+		max[i] = 0xff
+	}
+	if s := max.String(); s != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Errorf("max ULID = %s", s)
+	}
+	if s := (ULID{}).String(); s != strings.Repeat("0", 26) {
+		t.Errorf("zero ULID = %s", s)
+	}
+
+	// the example of the spec
+	u, err := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Time().UnixMilli() != 1469922850259 {
+		t.Errorf("Time() = %d", u.Time().UnixMilli())
+	}
+	if lower, err := ParseULID("01arz3ndektsv4rrffq69g5fav"); err != nil || lower != u {
+		t.Errorf("lower case ULID = %v, %v", lower, err)
+	}
+
+	for i := 0; i < 100; i++ {
+		u := NewULID()
+		if back, err := ParseULID(u.String()); err != nil || back != u {
+			t.Fatalf("round trip of %s = %s, %v", u, back, err)
+		}
+	}
+
+	data, _ := json.Marshal(u)
+	var back ULID
+	if err := json.Unmarshal(data, &back); err != nil || back != u {
+		t.Errorf("JSON round trip = %s, %v", back, err)
+	}
+
+	for _, s := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU"} {
+		if _, err := ParseULID(s); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("ParseULID(%q) error = %v", s, err)
+		}
+	}
+}
+
+func TestULIDOverflow(t *testing.T) {
+	g := NewULIDGenerator(bytes.NewReader(bytes.Repeat([]byte{0xff}, 10)))
+	now := time.Now()
+	if _, err := g.New(now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.New(now); !errors.Is(err, ErrMonotonicOverflow) {
+		t.Errorf("error = %v, want ErrMonotonicOverflow", err)
+	}
+}
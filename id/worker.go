@@ -0,0 +1,237 @@
+package id
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	goRedis "github.com/go-redis/redis/v8"
+
+	"github.com/hy-shine/gotiny/db/redis"
+	"github.com/hy-shine/gotiny/rand"
+)
+
+var (
+	// ErrNoWorkerID is returned when every worker id is leased.
+	ErrNoWorkerID = errors.New("no free worker id")
+	// ErrLeaseLost is reported when a worker id lease was not renewed in
+	// time or was taken over.
+	ErrLeaseLost = errors.New("worker id lease lost")
+	// ErrNoRedisClient is returned when no client is configured and
+	// db/redis has not been initialised.
+	ErrNoRedisClient = errors.New("redis client not initialised")
+)
+
+// RedisClient is the part of the go-redis client the Redis-backed
+// allocators use. *goRedis.Client from db/redis implements it, tests can
+// pass an in-process stand-in.
+type RedisClient interface {
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *goRedis.BoolCmd
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) *goRedis.Cmd
+	IncrBy(ctx context.Context, key string, value int64) *goRedis.IntCmd
+}
+
+func redisClient(c RedisClient) (RedisClient, error) {
+	if c == nil {
+		if rc := redis.GetInstance(); rc != nil {
+			return rc, nil
+		}
+		return nil, ErrNoRedisClient
+	}
+	if rc, ok := c.(*goRedis.Client); ok && rc == nil {
+		return nil, ErrNoRedisClient
+	}
+	return c, nil
+}
+
+// Lua scripts run atomically, they only touch a key still owned by ARGV[1].
+const (
+	renewScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+else
+    return 0
+end`
+	releaseScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("DEL", KEYS[1])
+else
+    return 0
+end`
+)
+
+// WorkerLeaseConfig configures LeaseWorkerID. The zero value of a field
+// selects its default.
+type WorkerLeaseConfig struct {
+	// Client defaults to redis.GetInstance().
+	Client RedisClient
+	// Prefix of the keys, one per worker id, "id:worker:" by default.
+	Prefix string
+	// MaxWorkers is the number of worker ids, 1024 by default to match
+	// the 10 node bits of a default Snowflake.
+	MaxWorkers int64
+	// TTL is how long a lease outlives its last heartbeat, 30s by default.
+	TTL time.Duration
+	// Heartbeat is the renewal interval, TTL/3 by default. It must be
+	// shorter than TTL, the lease is reported lost once it was not renewed
+	// for TTL-Heartbeat.
+	Heartbeat time.Duration
+	// Owner identifies this process in the keys, the host name and pid
+	// with a random suffix by default.
+	Owner string
+	// OnLost is called once if the lease is lost, so the process can stop
+	// generating ids with a worker id another one may now hold.
+	OnLost func(err error)
+}
+
+// WorkerLease is a worker id held in Redis for as long as the process
+// renews it, so replicas get distinct Snowflake nodes without
+// configuration:
+//
+//	lease, err := id.LeaseWorkerID(ctx, id.WorkerLeaseConfig{})
+//	defer lease.Release(context.Background())
+//	sf, err := id.NewSnowflake(id.SnowflakeConfig{Node: lease.ID()})
+type WorkerLease struct {
+	cfg WorkerLeaseConfig
+	id  int64
+	key string
+
+	stop     chan struct{}
+	done     chan struct{}
+	lost     chan struct{}
+	lostOnce sync.Once
+	stopOnce sync.Once
+}
+
+// LeaseWorkerID leases the first free worker id, probing from a random
+// one, and renews it in the background until Release. It returns
+// ErrNoWorkerID if all MaxWorkers ids are taken, and ErrNoRedisClient if
+// there is no client to use.
+func LeaseWorkerID(ctx context.Context, cfg WorkerLeaseConfig) (*WorkerLease, error) {
+	client, err := redisClient(cfg.Client)
+	if err != nil {
+		return nil, fmt.Errorf("lease worker id: %w", err)
+	}
+	cfg.Client = client
+	if cfg.Prefix == "" {
+		cfg.Prefix = "id:worker:"
+	}
+	if cfg.MaxWorkers <= 0 {
+		cfg.MaxWorkers = 1024
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * time.Second
+	}
+	if cfg.Heartbeat <= 0 {
+		cfg.Heartbeat = cfg.TTL / 3
+	}
+	if cfg.Heartbeat >= cfg.TTL {
+		return nil, fmt.Errorf("lease worker id: heartbeat %v is not shorter than ttl %v", cfg.Heartbeat, cfg.TTL)
+	}
+	if cfg.Owner == "" {
+		host, _ := os.Hostname()
+		cfg.Owner = host + ":" + strconv.Itoa(os.Getpid()) + ":" + rand.RandString(8, rand.Alphanumeric)
+	}
+
+	start, _ := rand.IntRange(nil, 0, cfg.MaxWorkers-1)
+	for i := int64(0); i < cfg.MaxWorkers; i++ {
+		id := (start + i) % cfg.MaxWorkers
+		key := cfg.Prefix + strconv.FormatInt(id, 10)
+		sent := time.Now()
+		ok, err := cfg.Client.SetNX(ctx, key, cfg.Owner, cfg.TTL).Result()
+		if err != nil {
+			return nil, fmt.Errorf("lease worker id: %w", err)
+		}
+		if ok {
+			l := &WorkerLease{
+				cfg:  cfg,
+				id:   id,
+				key:  key,
+				stop: make(chan struct{}),
+				done: make(chan struct{}),
+				lost: make(chan struct{}),
+			}
+			go l.heartbeat(sent)
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: all %d are leased", ErrNoWorkerID, cfg.MaxWorkers)
+}
+
+// ID returns the leased worker id.
+func (l *WorkerLease) ID() int64 {
+	return l.id
+}
+
+// Lost is closed when the lease is lost.
+func (l *WorkerLease) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// heartbeat renews the key until Release. The key expires no sooner than
+// TTL after the last successful renewal was sent, the lease is given up one
+// Heartbeat before that so no other process can lease the id while this
+// one still uses it.
+func (l *WorkerLease) heartbeat(renewed time.Time) {
+	defer close(l.done)
+	ticker := time.NewTicker(l.cfg.Heartbeat)
+	defer ticker.Stop()
+	margin := l.cfg.TTL - l.cfg.Heartbeat
+	deadline := time.NewTimer(time.Until(renewed.Add(margin)))
+	defer deadline.Stop()
+	var lastErr error
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-deadline.C:
+			l.setLost(fmt.Errorf("%w: not renewed for %v: %v", ErrLeaseLost, margin, lastErr))
+			return
+		case <-ticker.C:
+		}
+
+		sent := time.Now()
+		ctx, cancel := context.WithDeadline(context.Background(), renewed.Add(margin))
+		n, err := l.cfg.Client.Eval(ctx, renewScript, []string{l.key}, l.cfg.Owner, l.cfg.TTL.Milliseconds()).Int64()
+		cancel()
+		switch {
+		case err != nil:
+			// retried on the next tick until the deadline
+			lastErr = err
+		case n == 1:
+			renewed = sent
+			if !deadline.Stop() {
+				select {
+				case <-deadline.C:
+				default:
+				}
+			}
+			deadline.Reset(time.Until(renewed.Add(margin)))
+		default:
+			l.setLost(fmt.Errorf("%w: %s is no longer ours", ErrLeaseLost, l.key))
+			return
+		}
+	}
+}
+
+func (l *WorkerLease) setLost(err error) {
+	l.lostOnce.Do(func() {
+		close(l.lost)
+		if l.cfg.OnLost != nil {
+			l.cfg.OnLost(err)
+		}
+	})
+}
+
+// Release stops the heartbeat and frees the worker id if this process
+// still holds it. It is safe to call more than once.
+func (l *WorkerLease) Release(ctx context.Context) error {
+	l.stopOnce.Do(func() { close(l.stop) })
+	<-l.done
+	if err := l.cfg.Client.Eval(ctx, releaseScript, []string{l.key}, l.cfg.Owner).Err(); err != nil {
+		return fmt.Errorf("release worker id %d: %w", l.id, err)
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package id
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	goRedis "github.com/go-redis/redis/v8"
+)
+
+// fakeRedis is an in-process stand-in for the commands and scripts the
+// allocators send to Redis.
+type fakeRedis struct {
+	mu      sync.Mutex
+	vals    map[string]string
+	expires map[string]time.Time
+	err     error         // returned by every command when set
+	delay   chan struct{} // IncrBy waits on it when set
+	incrs   int
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{vals: make(map[string]string), expires: make(map[string]time.Time)}
+}
+
+func (f *fakeRedis) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// get returns the live value of key, f.mu must be held.
+func (f *fakeRedis) get(key string) (string, bool) {
+	if exp, ok := f.expires[key]; ok && !time.Now().Before(exp) {
+		delete(f.vals, key)
+		delete(f.expires, key)
+	}
+	v, ok := f.vals[key]
+	return v, ok
+}
+
+func (f *fakeRedis) Get(key string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.get(key)
+}
+
+func (f *fakeRedis) Set(key, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.vals[key] = value
+	delete(f.expires, key)
+}
+
+func (f *fakeRedis) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *goRedis.BoolCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return goRedis.NewBoolResult(false, f.err)
+	}
+	if _, ok := f.get(key); ok {
+		return goRedis.NewBoolResult(false, nil)
+	}
+	f.vals[key] = fmt.Sprint(value)
+	if expiration > 0 {
+		f.expires[key] = time.Now().Add(expiration)
+	}
+	return goRedis.NewBoolResult(true, nil)
+}
+
+func (f *fakeRedis) Eval(ctx context.Context, script string, keys []string, args ...interface{}) *goRedis.Cmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return goRedis.NewCmdResult(nil, f.err)
+	}
+	if v, ok := f.get(keys[0]); !ok || v != fmt.Sprint(args[0]) {
+		return goRedis.NewCmdResult(int64(0), nil)
+	}
+	switch script {
+	case renewScript:
+		ms, _ := strconv.ParseInt(fmt.Sprint(args[1]), 10, 64)
+		f.expires[keys[0]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+	case releaseScript:
+		delete(f.vals, keys[0])
+		delete(f.expires, keys[0])
+	default:
+		return goRedis.NewCmdResult(nil, errors.New("unknown script"))
+	}
+	return goRedis.NewCmdResult(int64(1), nil)
+}
+
+func (f *fakeRedis) IncrBy(ctx context.Context, key string, value int64) *goRedis.IntCmd {
+	f.mu.Lock()
+	delay := f.delay
+	f.mu.Unlock()
+	if delay != nil {
+		select {
+		case <-delay:
+		case <-ctx.Done():
+			return goRedis.NewIntResult(0, ctx.Err())
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return goRedis.NewIntResult(0, f.err)
+	}
+	v, _ := f.get(key)
+	n, _ := strconv.ParseInt(v, 10, 64)
+	n += value
+	f.vals[key] = strconv.FormatInt(n, 10)
+	f.incrs++
+	return goRedis.NewIntResult(n, nil)
+}
+
+func TestLeaseWorkerID(t *testing.T) {
+	r := newFakeRedis()
+	ctx := context.Background()
+	cfg := WorkerLeaseConfig{Client: r, MaxWorkers: 4, TTL: time.Minute}
+
+	var leases []*WorkerLease
+	seen := make(map[int64]bool)
+	for i := 0; i < 4; i++ {
+		l, err := LeaseWorkerID(ctx, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.ID() < 0 || l.ID() >= 4 || seen[l.ID()] {
+			t.Fatalf("worker id %d leased twice or out of range", l.ID())
+		}
+		seen[l.ID()] = true
+		if _, ok := r.Get("id:worker:" + strconv.FormatInt(l.ID(), 10)); !ok {
+			t.Fatalf("worker id %d has no key", l.ID())
+		}
+		leases = append(leases, l)
+	}
+	if _, err := LeaseWorkerID(ctx, cfg); !errors.Is(err, ErrNoWorkerID) {
+		t.Fatalf("got %v, want ErrNoWorkerID", err)
+	}
+
+	freed := leases[2].ID()
+	if err := leases[2].Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := leases[2].Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	l, err := LeaseWorkerID(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.ID() != freed {
+		t.Fatalf("got worker id %d, want the released %d", l.ID(), freed)
+	}
+	leases[2] = l
+	for _, l := range leases {
+		if err := l.Release(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(r.vals) != 0 {
+		t.Fatalf("keys left after release: %v", r.vals)
+	}
+}
+
+func TestWorkerLeaseHeartbeat(t *testing.T) {
+	r := newFakeRedis()
+	ctx := context.Background()
+	l, err := LeaseWorkerID(ctx, WorkerLeaseConfig{Client: r, MaxWorkers: 1, TTL: 60 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Release(ctx)
+
+	// the heartbeat keeps the key well past its TTL
+	time.Sleep(200 * time.Millisecond)
+	if _, ok := r.Get("id:worker:0"); !ok {
+		t.Fatal("lease expired despite the heartbeat")
+	}
+	select {
+	case <-l.Lost():
+		t.Fatal("lease reported lost")
+	default:
+	}
+}
+
+func TestWorkerLeaseLost(t *testing.T) {
+	r := newFakeRedis()
+	ctx := context.Background()
+	lost := make(chan error, 1)
+	l, err := LeaseWorkerID(ctx, WorkerLeaseConfig{
+		Client:     r,
+		MaxWorkers: 1,
+		TTL:        time.Minute,
+		Heartbeat:  10 * time.Millisecond,
+		Owner:      "a",
+		OnLost:     func(err error) { lost <- err },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// another process took the key over
+	r.Set("id:worker:0", "b")
+	select {
+	case err := <-lost:
+		if !errors.Is(err, ErrLeaseLost) {
+			t.Fatalf("got %v, want ErrLeaseLost", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("lost lease not reported")
+	}
+	<-l.Lost()
+
+	// releasing must not delete the key of the new owner
+	if err := l.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := r.Get("id:worker:0"); v != "b" {
+		t.Fatalf("key is %q after release, want b", v)
+	}
+}
+
+func TestWorkerLeaseUnreachable(t *testing.T) {
+	r := newFakeRedis()
+	ctx := context.Background()
+	// true if the key was still live, so no one else could lease the id yet
+	lost := make(chan bool, 1)
+	l, err := LeaseWorkerID(ctx, WorkerLeaseConfig{
+		Client:     r,
+		MaxWorkers: 1,
+		TTL:        90 * time.Millisecond,
+		Heartbeat:  30 * time.Millisecond,
+		OnLost: func(err error) {
+			_, ok := r.Get("id:worker:0")
+			lost <- ok && errors.Is(err, ErrLeaseLost)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.setErr(errors.New("connection refused"))
+	select {
+	case live := <-lost:
+		if !live {
+			t.Fatal("lease reported lost only after its key expired")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("lease not lost while Redis is down")
+	}
+	<-l.Lost()
+	if err := l.Release(ctx); err == nil {
+		t.Fatal("release succeeded while Redis is down")
+	}
+
+	if _, err := LeaseWorkerID(ctx, WorkerLeaseConfig{Client: r}); err == nil {
+		t.Fatal("lease succeeded while Redis is down")
+	}
+	if _, err := LeaseWorkerID(ctx, WorkerLeaseConfig{Client: newFakeRedis(), TTL: time.Second, Heartbeat: time.Second}); err == nil {
+		t.Fatal("lease accepted a heartbeat as long as the ttl")
+	}
+}
+
+func TestNoRedisClient(t *testing.T) {
+	ctx := context.Background()
+	// db/redis is never initialised in these tests
+	if _, err := LeaseWorkerID(ctx, WorkerLeaseConfig{}); !errors.Is(err, ErrNoRedisClient) {
+		t.Errorf("LeaseWorkerID error = %v, want ErrNoRedisClient", err)
+	}
+	if _, err := NewSegment(ctx, SegmentConfig{Key: "k"}); !errors.Is(err, ErrNoRedisClient) {
+		t.Errorf("NewSegment error = %v, want ErrNoRedisClient", err)
+	}
+	var nilClient *goRedis.Client
+	if _, err := NewSegment(ctx, SegmentConfig{Client: nilClient, Key: "k"}); !errors.Is(err, ErrNoRedisClient) {
+		t.Errorf("NewSegment(nil *Client) error = %v, want ErrNoRedisClient", err)
+	}
+}
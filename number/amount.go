@@ -0,0 +1,346 @@
+package number
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+const chineseMoneyDigits = "零壹贰叁肆伍陆柒捌玖"
+
+var chineseMoneySmallUnits = [...]string{"", "拾", "佰", "仟"}
+
+// cents returns |d| rounded half up to 2 places, in cents.
+func cents(d Decimal) (uint64, error) {
+	c, ok := d.Abs().Round(2, RoundHalfUp).Mul(New(100, 0)).IntPart()
+	if !ok {
+		return 0, fmt.Errorf("%w: amount %s", ErrOverflow, d)
+	}
+	return uint64(c), nil
+}
+
+// FormatChineseMoney writes the amount d in Chinese uppercase money
+// (大写金额), as printed on invoices and contracts: 12300 is
+// "壹万贰仟叁佰元整", 1005.5 "壹仟零伍元伍角整", 10.05 "壹拾元零伍分",
+// 0.5 "伍角整" and -3 "负叁元整". d is rounded half up to 分.
+func FormatChineseMoney(d Decimal) (string, error) {
+	c, err := cents(d)
+	if err != nil {
+		return "", err
+	}
+	if c == 0 {
+		return "零元整", nil
+	}
+
+	digits := []rune(chineseMoneyDigits)
+	yuan, jiao, fen := c/100, c/10%10, c%10
+	var sb strings.Builder
+	if d.Sign() < 0 {
+		sb.WriteString("负")
+	}
+	if yuan > 0 {
+		sb.WriteString(formatChinese(yuan, digits, chineseMoneySmallUnits[:], "亿", "万"))
+		sb.WriteString("元")
+	}
+	switch {
+	case jiao == 0 && fen == 0:
+		sb.WriteString("整")
+	case jiao == 0:
+		if yuan > 0 {
+			sb.WriteRune(digits[0])
+		}
+		sb.WriteRune(digits[fen])
+		sb.WriteString("分")
+	default:
+		sb.WriteRune(digits[jiao])
+		sb.WriteString("角")
+		if fen == 0 {
+			sb.WriteString("整")
+		} else {
+			sb.WriteRune(digits[fen])
+			sb.WriteString("分")
+		}
+	}
+	return sb.String(), nil
+}
+
+// ParseChineseMoney parses an amount in Chinese money, the inverse of
+// FormatChineseMoney, to check what was printed or typed. It accepts the
+// 人民币 prefix, 圆 for 元, 正 for 整 and the lower-case digits too.
+func ParseChineseMoney(s string) (Decimal, error) {
+	invalid := fmt.Errorf("%w: amount %q", ErrInvalidNumber, s)
+	rs := []rune(strings.TrimPrefix(strings.TrimSpace(s), "人民币"))
+	neg := len(rs) > 0 && rs[0] == '负'
+	if neg {
+		rs = rs[1:]
+	}
+	if n := len(rs); n > 0 && (rs[n-1] == '整' || rs[n-1] == '正') {
+		rs = rs[:n-1]
+	}
+	if len(rs) == 0 {
+		return Decimal{}, invalid
+	}
+
+	var yuan uint64
+	if i := strings.IndexAny(string(rs), "元圆"); i >= 0 {
+		i = len([]rune(string(rs)[:i]))
+		v, ok := parseChinese(rs[:i], false)
+		if !ok {
+			return Decimal{}, invalid
+		}
+		yuan, rs = v, rs[i+1:]
+	}
+
+	// the rest is [零][d角][[零]d分]
+	var frac uint64
+	for _, u := range []struct {
+		unit  rune
+		value uint64
+	}{{'角', 10}, {'分', 1}} {
+		if len(rs) > 0 && rs[0] == '零' {
+			rs = rs[1:]
+		}
+		if len(rs) >= 2 && rs[1] == u.unit {
+			d, ok := chineseDigitValues[rs[0]]
+			if !ok {
+				return Decimal{}, invalid
+			}
+			frac += d * u.value
+			rs = rs[2:]
+		}
+	}
+	if len(rs) > 0 {
+		return Decimal{}, invalid
+	}
+
+	hi, lo := bits.Mul64(yuan, 100)
+	if hi != 0 || lo+frac > math.MaxInt64 {
+		return Decimal{}, fmt.Errorf("%w: amount %q", ErrOverflow, s)
+	}
+	v := int64(lo + frac)
+	if neg {
+		v = -v
+	}
+	return New(v, 2), nil
+}
+
+var (
+	englishOnes = [...]string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+		"seventeen", "eighteen", "nineteen",
+	}
+	englishTens   = [...]string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	englishScales = [...]string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// FormatEnglish writes v in English words, in the US style without "and":
+// 1234 is "one thousand two hundred thirty-four" and -15 "minus fifteen".
+func FormatEnglish[T constraints.Integer](v T) string {
+	if v == 0 {
+		return englishOnes[0]
+	}
+	s := formatEnglish(abs(v))
+	if v < 0 {
+		s = "minus " + s
+	}
+	return s
+}
+
+func formatEnglish(u uint64) string {
+	var groups []string
+	for scale := 0; u > 0; scale, u = scale+1, u/1000 {
+		g := u % 1000
+		if g == 0 {
+			continue
+		}
+		words := formatEnglishHundreds(g)
+		if scale > 0 {
+			words += " " + englishScales[scale]
+		}
+		groups = append(groups, words)
+	}
+	for i, j := 0, len(groups)-1; i < j; i, j = i+1, j-1 {
+		groups[i], groups[j] = groups[j], groups[i]
+	}
+	return strings.Join(groups, " ")
+}
+
+// formatEnglishHundreds writes 0 < n < 1000.
+func formatEnglishHundreds(n uint64) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, englishOnes[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		words = append(words, englishOnes[n])
+	case n%10 == 0:
+		words = append(words, englishTens[n/10])
+	default:
+		words = append(words, englishTens[n/10]+"-"+englishOnes[n%10])
+	}
+	return strings.Join(words, " ")
+}
+
+// FormatEnglishAmount writes the amount d as on a cheque, the whole part
+// in words and the cents as a fraction: 1234.5 is
+// "one thousand two hundred thirty-four and 50/100". d is rounded half up
+// to cents.
+func FormatEnglishAmount(d Decimal) (string, error) {
+	c, err := cents(d)
+	if err != nil {
+		return "", err
+	}
+	s := fmt.Sprintf("%s and %02d/100", FormatEnglish(c/100), c%100)
+	if d.Sign() < 0 && c > 0 {
+		s = "minus " + s
+	}
+	return s, nil
+}
+
+var englishValues = func() map[string]uint64 {
+	m := make(map[string]uint64, len(englishOnes)+len(englishTens))
+	for i, w := range englishOnes {
+		m[w] = uint64(i)
+	}
+	for i, w := range englishTens[2:] {
+		m[w] = uint64(i+2) * 10
+	}
+	return m
+}()
+
+var englishScaleValues = map[string]uint64{
+	"thousand": 1e3, "million": 1e6, "billion": 1e9, "trillion": 1e12,
+	"quadrillion": 1e15, "quintillion": 1e18,
+}
+
+// ParseEnglish parses a number in English words, the inverse of
+// FormatEnglish. It ignores case and "and", as in the British
+// "one hundred and five", and accepts "minus" or "negative" in front.
+func ParseEnglish(s string) (int64, error) {
+	words := strings.Fields(strings.ToLower(strings.ReplaceAll(s, "-", " ")))
+	neg := false
+	if len(words) > 0 && (words[0] == "minus" || words[0] == "negative") {
+		neg, words = true, words[1:]
+	}
+	u, ok := parseEnglish(words)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidNumber, s)
+	}
+	if neg {
+		if u > 1<<63 {
+			return 0, fmt.Errorf("%w: %q", ErrOverflow, s)
+		}
+		return -int64(u), nil
+	}
+	if u > math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %q", ErrOverflow, s)
+	}
+	return int64(u), nil
+}
+
+// The kinds of number words, a group below a thousand reads
+// [ones hundred] [tens [ones] | teens | ones].
+const (
+	wordStart = iota
+	wordOnes
+	wordTeens
+	wordTens
+	wordHundred
+)
+
+func parseEnglish(words []string) (uint64, bool) {
+	var total, group uint64
+	seen, zero := false, false
+	last := wordStart
+	lastScale := uint64(math.MaxUint64)
+	for _, w := range words {
+		if w == "and" {
+			continue
+		}
+		if zero {
+			return 0, false // "zero" stands alone
+		}
+		if v, ok := englishValues[w]; ok {
+			kind := wordOnes
+			switch {
+			case v == 0:
+				if seen {
+					return 0, false
+				}
+				zero = true
+			case v >= 20:
+				kind = wordTens
+			case v >= 10:
+				kind = wordTeens
+			}
+			// ones follow tens, anything but another ones word follows a
+			// hundred or starts a group
+			if last != wordStart && last != wordHundred && !(kind == wordOnes && last == wordTens) {
+				return 0, false
+			}
+			seen = true
+			group += v
+			last = kind
+			continue
+		}
+		seen = true
+		if w == "hundred" {
+			if last != wordOnes || group >= 10 {
+				return 0, false
+			}
+			group *= 100
+			last = wordHundred
+			continue
+		}
+		scale, ok := englishScaleValues[w]
+		if !ok || group == 0 || group >= 1000 || scale >= lastScale {
+			return 0, false
+		}
+		hi, lo := bits.Mul64(group, scale)
+		if hi != 0 || total+lo < total {
+			return 0, false
+		}
+		total, group, lastScale, last = total+lo, 0, scale, wordStart
+	}
+	if !seen || group >= 1000 || total+group < total {
+		return 0, false
+	}
+	return total + group, true
+}
+
+// ParseEnglishAmount parses an amount written by FormatEnglishAmount,
+// the cents as "NN/100" after the words.
+func ParseEnglishAmount(s string) (Decimal, error) {
+	invalid := fmt.Errorf("%w: amount %q", ErrInvalidNumber, s)
+	words, frac := strings.TrimSpace(s), uint64(0)
+	if i := strings.LastIndexByte(words, ' '); i >= 0 && strings.HasSuffix(words, "/100") {
+		c, err := strconv.ParseUint(words[i+1:len(words)-4], 10, 64)
+		if err != nil || c >= 100 {
+			return Decimal{}, invalid
+		}
+		words, frac = words[:i], c
+	}
+	n, err := ParseEnglish(words)
+	if err != nil {
+		return Decimal{}, err
+	}
+	neg := n < 0 || strings.HasPrefix(strings.ToLower(words), "minus") || strings.HasPrefix(strings.ToLower(words), "negative")
+	u := abs(n)
+	hi, lo := bits.Mul64(u, 100)
+	if hi != 0 || lo+frac > math.MaxInt64 {
+		return Decimal{}, fmt.Errorf("%w: amount %q", ErrOverflow, s)
+	}
+	v := int64(lo + frac)
+	if neg {
+		v = -v
+	}
+	return New(v, 2), nil
+}
@@ -0,0 +1,116 @@
+package number
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChineseMoney(t *testing.T) {
+	cases := []struct {
+		amount string
+		want   string
+	}{
+		{"0", "零元整"},
+		{"12300", "壹万贰仟叁佰元整"},
+		{"1005.5", "壹仟零伍元伍角整"},
+		{"10.05", "壹拾元零伍分"},
+		{"0.5", "伍角整"},
+		{"0.05", "伍分"},
+		{"0.25", "贰角伍分"},
+		{"-3", "负叁元整"},
+		{"100000001.01", "壹亿零壹元零壹分"},
+		{"1500000000000", "壹万伍仟亿元整"},
+		{"60036.78", "陆万零叁拾陆元柒角捌分"},
+	}
+	for _, c := range cases {
+		got, err := FormatChineseMoney(MustParse(c.amount))
+		if err != nil || got != c.want {
+			t.Errorf("FormatChineseMoney(%s) = %q, %v, want %q", c.amount, got, err, c.want)
+		}
+		back, err := ParseChineseMoney(c.want)
+		if err != nil || !back.Equal(MustParse(c.amount)) {
+			t.Errorf("ParseChineseMoney(%q) = %s, %v", c.want, back, err)
+		}
+	}
+
+	if got, _ := FormatChineseMoney(MustParse("1.005")); got != "壹元零壹分" {
+		t.Errorf("FormatChineseMoney(1.005) = %q", got)
+	}
+	if got, _ := FormatChineseMoney(MustParse("-0.001")); got != "零元整" {
+		t.Errorf("FormatChineseMoney(-0.001) = %q", got)
+	}
+	if _, err := FormatChineseMoney(MustParse("1e30")); !errors.Is(err, ErrOverflow) {
+		t.Errorf("FormatChineseMoney(1e30) error = %v", err)
+	}
+
+	if got, err := ParseChineseMoney("人民币壹佰圆正"); err != nil || !got.Equal(NewFromInt(100)) {
+		t.Errorf("ParseChineseMoney(人民币壹佰圆正) = %s, %v", got, err)
+	}
+	for _, s := range []string{"", "整", "元整", "壹元伍", "壹元伍毛", "叁角贰角", "壹壹元", "壹万壹壹元", "壹万零零元"} {
+		if _, err := ParseChineseMoney(s); !errors.Is(err, ErrInvalidNumber) {
+			t.Errorf("ParseChineseMoney(%q) error = %v", s, err)
+		}
+	}
+}
+
+func TestEnglish(t *testing.T) {
+	cases := []struct {
+		n    int64
+		want string
+	}{
+		{0, "zero"},
+		{7, "seven"},
+		{15, "fifteen"},
+		{40, "forty"},
+		{99, "ninety-nine"},
+		{100, "one hundred"},
+		{1234, "one thousand two hundred thirty-four"},
+		{1000001, "one million one"},
+		{-15, "minus fifteen"},
+		{9223372036854775807, "nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred seven"},
+	}
+	for _, c := range cases {
+		if got := FormatEnglish(c.n); got != c.want {
+			t.Errorf("FormatEnglish(%d) = %q, want %q", c.n, got, c.want)
+		}
+		if got, err := ParseEnglish(c.want); err != nil || got != c.n {
+			t.Errorf("ParseEnglish(%q) = %d, %v", c.want, got, err)
+		}
+	}
+	if got, err := ParseEnglish("One Hundred and Five"); err != nil || got != 105 {
+		t.Errorf("ParseEnglish(British) = %d, %v", got, err)
+	}
+	for _, s := range []string{"", "and", "hundred", "one thousand thousand", "two million three billion", "twelve hundred", "one apple",
+		"one one one", "nineteen five", "twenty thirty", "five twenty", "one hundred zero", "zero one", "one and one"} {
+		if _, err := ParseEnglish(s); !errors.Is(err, ErrInvalidNumber) {
+			t.Errorf("ParseEnglish(%q) error = %v", s, err)
+		}
+	}
+}
+
+func TestEnglishAmount(t *testing.T) {
+	cases := []struct {
+		amount string
+		want   string
+	}{
+		{"1234.5", "one thousand two hundred thirty-four and 50/100"},
+		{"0.07", "zero and 07/100"},
+		{"-20", "minus twenty and 00/100"},
+	}
+	for _, c := range cases {
+		got, err := FormatEnglishAmount(MustParse(c.amount))
+		if err != nil || got != c.want {
+			t.Errorf("FormatEnglishAmount(%s) = %q, %v, want %q", c.amount, got, err, c.want)
+		}
+		back, err := ParseEnglishAmount(c.want)
+		if err != nil || !back.Equal(MustParse(c.amount)) {
+			t.Errorf("ParseEnglishAmount(%q) = %s, %v", c.want, back, err)
+		}
+	}
+	if got, err := ParseEnglishAmount("ten"); err != nil || !got.Equal(NewFromInt(10)) {
+		t.Errorf("ParseEnglishAmount(ten) = %s, %v", got, err)
+	}
+	if _, err := ParseEnglishAmount("ten and 100/100"); !errors.Is(err, ErrInvalidNumber) {
+		t.Errorf("ParseEnglishAmount(100 cents) error = %v", err)
+	}
+}
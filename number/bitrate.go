@@ -0,0 +1,154 @@
+package number
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BitRate is a number of bits per second that parses and prints with SI
+// units, such as "100Mbps", "1.5 Gbit/s" or "10MB/s". It implements
+// flag.Value, encoding.TextMarshaler, encoding.TextUnmarshaler and JSON,
+// which also accepts a plain number of bits per second.
+type BitRate int64
+
+// Bit rates are decimal, as network speeds always are. BitPerSecond is one
+// bit per second, not one byte as the Bps abbreviation would suggest.
+const (
+	BitPerSecond BitRate = 1
+	Kbps                 = 1000 * BitPerSecond
+	Mbps                 = 1000 * Kbps
+	Gbps                 = 1000 * Mbps
+	Tbps                 = 1000 * Gbps
+)
+
+var rateUnits = []struct {
+	rate   BitRate
+	digits int
+	name   string
+}{{Tbps, 12, "Tbps"}, {Gbps, 9, "Gbps"}, {Mbps, 6, "Mbps"}, {Kbps, 3, "Kbps"}}
+
+var ratePrefixes = map[byte]BitRate{'k': Kbps, 'm': Mbps, 'g': Gbps, 't': Tbps}
+
+// ParseBitRate parses a rate such as "512kbps", "100 Mbps", "1.5Gbit/s"
+// or "10MB/s". The prefixes k, M, G and T are case-insensitive powers of
+// 1000; an upper-case B or the word byte counts bytes, 8 bits each.
+func ParseBitRate(s string) (BitRate, error) {
+	num, unit := splitUnit(s)
+	mult, ok := parseRateUnit(unit)
+	if num == "" || !ok {
+		return 0, fmt.Errorf("invalid bit rate %q", s)
+	}
+	v, ok := scaleInt(num, int64(mult))
+	if !ok || v < 0 {
+		return 0, fmt.Errorf("invalid bit rate %q", s)
+	}
+	return BitRate(v), nil
+}
+
+func parseRateUnit(unit string) (BitRate, bool) {
+	lower := strings.ToLower(unit)
+	switch {
+	case strings.HasSuffix(lower, "/s"):
+		unit = unit[:len(unit)-2]
+	case strings.HasSuffix(lower, "ps"):
+		unit = unit[:len(unit)-2]
+	}
+
+	mult := BitPerSecond
+	if unit != "" {
+		if m, ok := ratePrefixes[strings.ToLower(unit[:1])[0]]; ok {
+			mult, unit = m, unit[1:]
+		}
+	}
+	switch {
+	case unit == "B" || strings.EqualFold(unit, "byte") || strings.EqualFold(unit, "bytes"):
+		return 8 * mult, true
+	case unit == "" || unit == "b" || strings.EqualFold(unit, "bit") || strings.EqualFold(unit, "bits"):
+		return mult, true
+	}
+	return 0, false
+}
+
+// MustParseBitRate is like ParseBitRate but panics on error.
+func MustParseBitRate(s string) BitRate {
+	r, err := ParseBitRate(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// BytesPerSecond returns r in bytes per second.
+func (r BitRate) BytesPerSecond() float64 {
+	return float64(r) / 8
+}
+
+// String formats r with the largest unit below it and as many decimals as
+// needed, so it parses back to the same rate: 1500000000 is "1.5Gbps".
+func (r BitRate) String() string {
+	abs := r
+	if abs < 0 {
+		abs = -abs
+	}
+	for _, u := range rateUnits {
+		if abs >= u.rate {
+			return shiftDecimal(strconv.FormatInt(int64(abs), 10), u.digits, r < 0) + u.name
+		}
+	}
+	return strconv.FormatInt(int64(r), 10) + "bps"
+}
+
+// shiftDecimal divides the decimal digits by 10^n, dropping trailing zeros.
+func shiftDecimal(digits string, n int, neg bool) string {
+	intPart, frac := digits[:len(digits)-n], strings.TrimRight(digits[len(digits)-n:], "0")
+	s := intPart
+	if frac != "" {
+		s += "." + frac
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Humanize formats r with the largest unit below it and at most places
+// decimals: 1234567 is "1.23Mbps" with two places.
+func (r BitRate) Humanize(places int) string {
+	abs := r
+	if abs < 0 {
+		abs = -abs
+	}
+	for _, u := range rateUnits {
+		if abs >= u.rate {
+			v := Round(float64(r)/float64(u.rate), places, RoundHalfUp)
+			return strconv.FormatFloat(v, 'f', -1, 64) + u.name
+		}
+	}
+	return strconv.FormatInt(int64(r), 10) + "bps"
+}
+
+// Set implements flag.Value.
+func (r *BitRate) Set(s string) error {
+	v, err := ParseBitRate(s)
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r BitRate) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *BitRate) UnmarshalText(text []byte) error {
+	return r.Set(string(text))
+}
+
+// UnmarshalJSON accepts a string with a unit or a number of bits per second.
+func (r *BitRate) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, r)
+}
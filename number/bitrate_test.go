@@ -0,0 +1,66 @@
+package number
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseBitRate(t *testing.T) {
+	cases := []struct {
+		s    string
+		want BitRate
+	}{
+		{"800", 800},
+		{"512kbps", 512 * Kbps},
+		{"100 Mbps", 100 * Mbps},
+		{"1.5Gbit/s", 1500 * Mbps},
+		{"10MB/s", 80 * Mbps},
+		{"1 KBps", 8 * Kbps},
+		{"2 tb/s", 2 * Tbps},
+		{"64 bps", 64},
+	}
+	for _, c := range cases {
+		if got, err := ParseBitRate(c.s); err != nil || got != c.want {
+			t.Errorf("ParseBitRate(%q) = %d, %v, want %d", c.s, got, err, c.want)
+		}
+	}
+	for _, s := range []string{"", "Mbps", "10 Xbps", "-1Mbps", "1 MiBps"} {
+		if _, err := ParseBitRate(s); err == nil {
+			t.Errorf("ParseBitRate(%q) should fail", s)
+		}
+	}
+}
+
+func TestBitRateFormat(t *testing.T) {
+	cases := []struct {
+		r        BitRate
+		s, human string
+	}{
+		{0, "0bps", "0bps"},
+		{999, "999bps", "999bps"},
+		{1500 * Mbps, "1.5Gbps", "1.5Gbps"},
+		{1234567, "1.234567Mbps", "1.23Mbps"},
+		{100 * Mbps, "100Mbps", "100Mbps"},
+	}
+	for _, c := range cases {
+		if got := c.r.String(); got != c.s {
+			t.Errorf("%d.String() = %q, want %q", int64(c.r), got, c.s)
+		}
+		if got := c.r.Humanize(2); got != c.human {
+			t.Errorf("%d.Humanize(2) = %q, want %q", int64(c.r), got, c.human)
+		}
+		if back, err := ParseBitRate(c.r.String()); err != nil || back != c.r {
+			t.Errorf("round trip of %d = %d, %v", int64(c.r), back, err)
+		}
+	}
+	if got := (80 * Mbps).BytesPerSecond(); got != 10e6 {
+		t.Errorf("BytesPerSecond = %v", got)
+	}
+
+	var cfg struct {
+		Limit BitRate `json:"limit"`
+	}
+	if err := json.Unmarshal([]byte(`{"limit":"1Gbps"}`), &cfg); err != nil || cfg.Limit != Gbps {
+		t.Errorf("Unmarshal = %v, %v", cfg.Limit, err)
+	}
+}
@@ -0,0 +1,210 @@
+package number
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a number of bytes that parses and prints with units,
+// such as "10MiB", "1.5 GB" or "512k", so config files can say
+// max_upload: 20MB. It implements flag.Value, encoding.TextMarshaler,
+// encoding.TextUnmarshaler and JSON, which also accepts a plain number of
+// bytes.
+type ByteSize int64
+
+// SI units are powers of 1000, IEC units powers of 1024.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB          = 1000 * KB
+	GB          = 1000 * MB
+	TB          = 1000 * GB
+	PB          = 1000 * TB
+	EB          = 1000 * PB
+
+	KiB ByteSize = 1 << 10
+	MiB ByteSize = 1 << 20
+	GiB ByteSize = 1 << 30
+	TiB ByteSize = 1 << 40
+	PiB ByteSize = 1 << 50
+	EiB ByteSize = 1 << 60
+)
+
+type sizeUnit struct {
+	size ByteSize
+	name string
+}
+
+// siUnits and iecUnits list the units from the largest.
+var (
+	siUnits  = []sizeUnit{{EB, "EB"}, {PB, "PB"}, {TB, "TB"}, {GB, "GB"}, {MB, "MB"}, {KB, "KB"}}
+	iecUnits = []sizeUnit{{EiB, "EiB"}, {PiB, "PiB"}, {TiB, "TiB"}, {GiB, "GiB"}, {MiB, "MiB"}, {KiB, "KiB"}}
+)
+
+// byteUnits maps lower-case unit names to their size. A bare prefix
+// such as k or M is binary, as in Docker and JVM options, while KB and MB
+// are decimal.
+var byteUnits = map[string]ByteSize{
+	"": Byte, "b": Byte, "byte": Byte, "bytes": Byte,
+	"kb": KB, "mb": MB, "gb": GB, "tb": TB, "pb": PB, "eb": EB,
+	"kib": KiB, "mib": MiB, "gib": GiB, "tib": TiB, "pib": PiB, "eib": EiB,
+	"ki": KiB, "mi": MiB, "gi": GiB, "ti": TiB, "pi": PiB, "ei": EiB,
+	"k": KiB, "m": MiB, "g": GiB, "t": TiB, "p": PiB, "e": EiB,
+}
+
+// splitUnit splits s into its numeric part and its unit, ignoring the
+// spaces around and between them.
+func splitUnit(s string) (num, unit string) {
+	s = strings.TrimSpace(s)
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == '-' || s[i] == '+') {
+		i++
+	}
+	return s[:i], strings.TrimSpace(s[i:])
+}
+
+// scaleInt returns num * mult as an int64, parsing integers exactly and
+// rounding fractional values to the nearest integer.
+func scaleInt(num string, mult int64) (int64, bool) {
+	if n, err := strconv.ParseInt(num, 10, 64); err == nil {
+		v := n * mult
+		if n != 0 && v/n != mult {
+			return 0, false
+		}
+		return v, true
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, false
+	}
+	v := math.Round(f * float64(mult))
+	if v >= math.MaxInt64 || v < math.MinInt64 {
+		return 0, false
+	}
+	return int64(v), true
+}
+
+// ParseByteSize parses a size such as "512", "10MiB", "1.5 GB" or "512k".
+// Units are case-insensitive: KB, MB, GB... are powers of 1000, KiB, MiB,
+// GiB... and the bare prefixes k, m, g... powers of 1024. A leading - gives
+// a negative size, such as a difference, so every String parses back.
+func ParseByteSize(s string) (ByteSize, error) {
+	num, unit := splitUnit(s)
+	mult, ok := byteUnits[strings.ToLower(unit)]
+	if num == "" || !ok {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	v, ok := scaleInt(num, int64(mult))
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	return ByteSize(v), nil
+}
+
+// MustParseByteSize is like ParseByteSize but panics on error.
+func MustParseByteSize(s string) ByteSize {
+	b, err := ParseByteSize(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Bytes returns b as an int64.
+func (b ByteSize) Bytes() int64 {
+	return int64(b)
+}
+
+// String formats b exactly with the unit giving the smallest number, so
+// it parses back to the same size: 10485760 is "10MiB", 20000000 "20MB"
+// and 1500 "1500B". Use HumanizeIEC or HumanizeSI for display.
+func (b ByteSize) String() string {
+	best := sizeUnit{Byte, "B"}
+	if b == 0 {
+		return "0B"
+	}
+	for _, units := range [][]sizeUnit{iecUnits, siUnits} {
+		for _, u := range units {
+			if b%u.size == 0 && u.size > best.size {
+				best = u
+				break
+			}
+		}
+	}
+	return strconv.FormatInt(int64(b/best.size), 10) + best.name
+}
+
+// HumanizeIEC formats b with the largest power of 1024 unit below it and
+// at most places decimals: 1536 is "1.5KiB".
+func (b ByteSize) HumanizeIEC(places int) string {
+	return b.humanize(places, iecUnits)
+}
+
+// HumanizeSI formats b with the largest power of 1000 unit below it and
+// at most places decimals: 1536 is "1.54KB" with two places.
+func (b ByteSize) HumanizeSI(places int) string {
+	return b.humanize(places, siUnits)
+}
+
+func (b ByteSize) humanize(places int, units []sizeUnit) string {
+	abs := b
+	if abs < 0 {
+		abs = -abs
+	}
+	for _, u := range units {
+		if abs >= u.size {
+			v := Round(float64(b)/float64(u.size), places, RoundHalfUp)
+			return strconv.FormatFloat(v, 'f', -1, 64) + u.name
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+// Set implements flag.Value.
+func (b *ByteSize) Set(s string) error {
+	v, err := ParseByteSize(s)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	return b.Set(string(text))
+}
+
+// UnmarshalJSON accepts a string with a unit or a number of bytes.
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, b)
+}
+
+// unmarshalJSONText decodes a JSON string, or a JSON number as an integer
+// without unit, through Set. null leaves the value unchanged.
+func unmarshalJSONText(data []byte, v interface{ Set(string) error }) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return v.Set(s)
+	}
+	n, ok := scaleInt(string(data), 1)
+	if !ok {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	return v.Set(strconv.FormatInt(n, 10))
+}
@@ -0,0 +1,110 @@
+package number
+
+import (
+	"encoding/json"
+	"flag"
+	"math"
+	"testing"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := []struct {
+		s    string
+		want ByteSize
+	}{
+		{"512", 512},
+		{"10MiB", 10 * MiB},
+		{"1.5 GB", 1500 * MB},
+		{"512k", 512 * KiB},
+		{"512K", 512 * KiB},
+		{"20MB", 20 * MB},
+		{" 2 gi ", 2 * GiB},
+		{"0.5KiB", 512},
+		{"3 bytes", 3},
+		{"8EiB", -1},
+	}
+	for _, c := range cases {
+		got, err := ParseByteSize(c.s)
+		if c.want < 0 {
+			if err == nil {
+				t.Errorf("ParseByteSize(%q) = %v, want overflow error", c.s, got)
+			}
+			continue
+		}
+		if err != nil || got != c.want {
+			t.Errorf("ParseByteSize(%q) = %d, %v, want %d", c.s, got, err, c.want)
+		}
+	}
+	for _, s := range []string{"", "MB", "10 XB", "1..2MB", "--1KB"} {
+		if _, err := ParseByteSize(s); err == nil {
+			t.Errorf("ParseByteSize(%q) should fail", s)
+		}
+	}
+
+	// negative sizes round-trip through text
+	for _, b := range []ByteSize{-1000, -3 * MiB, -1500, math.MinInt64} {
+		text, err := b.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got ByteSize
+		if err := got.UnmarshalText(text); err != nil || got != b {
+			t.Errorf("UnmarshalText(%q) = %d, %v, want %d", text, got, err, b)
+		}
+	}
+}
+
+func TestByteSizeFormat(t *testing.T) {
+	cases := []struct {
+		b          ByteSize
+		s, iec, si string
+	}{
+		{0, "0B", "0B", "0B"},
+		{1500, "1500B", "1.46KiB", "1.5KB"},
+		{10 * MiB, "10MiB", "10MiB", "10.49MB"},
+		{20 * MB, "20MB", "19.07MiB", "20MB"},
+		{1000 * MiB, "1000MiB", "1000MiB", "1.05GB"},
+		{1536, "1536B", "1.5KiB", "1.54KB"},
+	}
+	for _, c := range cases {
+		if got := c.b.String(); got != c.s {
+			t.Errorf("%d.String() = %q, want %q", int64(c.b), got, c.s)
+		}
+		if got := c.b.HumanizeIEC(2); got != c.iec {
+			t.Errorf("%d.HumanizeIEC(2) = %q, want %q", int64(c.b), got, c.iec)
+		}
+		if got := c.b.HumanizeSI(2); got != c.si {
+			t.Errorf("%d.HumanizeSI(2) = %q, want %q", int64(c.b), got, c.si)
+		}
+		if back, err := ParseByteSize(c.b.String()); err != nil || back != c.b {
+			t.Errorf("round trip of %d = %d, %v", int64(c.b), back, err)
+		}
+	}
+}
+
+func TestByteSizeEncoding(t *testing.T) {
+	var cfg struct {
+		MaxUpload ByteSize `json:"max_upload"`
+		Buffer    ByteSize `json:"buffer"`
+	}
+	if err := json.Unmarshal([]byte(`{"max_upload":"20MB","buffer":4096}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MaxUpload != 20*MB || cfg.Buffer != 4*KiB {
+		t.Errorf("Unmarshal = %+v", cfg)
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil || string(data) != `{"max_upload":"20MB","buffer":"4KiB"}` {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+	if err := json.Unmarshal([]byte(`{"max_upload":"20XB"}`), &cfg); err == nil {
+		t.Error("Unmarshal should reject an unknown unit")
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	size := 1 * MiB
+	fs.Var(&size, "size", "max size")
+	if err := fs.Parse([]string{"-size", "64k"}); err != nil || size != 64*KiB {
+		t.Errorf("flag = %v, %v", size, err)
+	}
+}
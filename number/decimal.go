@@ -0,0 +1,470 @@
+package number
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode tells how to round a value that falls between two
+// representable results.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest neighbor, halves away from zero:
+	// 2.5 => 3, -2.5 => -3. This is the rounding taught at school.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest neighbor, halves to the even one:
+	// 2.5 => 2, 3.5 => 4. Also called banker's rounding.
+	RoundHalfEven
+	// RoundDown truncates toward zero: 2.9 => 2, -2.9 => -2.
+	RoundDown
+	// RoundUp rounds away from zero: 2.1 => 3, -2.1 => -3.
+	RoundUp
+	// RoundCeiling rounds toward positive infinity: 2.1 => 3, -2.9 => -2.
+	RoundCeiling
+	// RoundFloor rounds toward negative infinity: 2.9 => 2, -2.1 => -3.
+	RoundFloor
+)
+
+var (
+	// ErrDivisionByZero is returned when dividing by a zero Decimal.
+	ErrDivisionByZero = errors.New("decimal division by zero")
+	// ErrInvalidDecimal is returned when parsing a malformed decimal.
+	ErrInvalidDecimal = errors.New("invalid decimal")
+)
+
+// Decimal is an exact decimal number, value * 10^-scale with an arbitrary
+// precision value, for amounts that must not suffer float64 rounding such
+// as 0.1 + 0.2. Add, Sub and Mul are exact, Div and Round take an explicit
+// number of places and a RoundingMode.
+//
+// Decimal is immutable and safe to copy, the zero value is 0.
+// It encodes to JSON as a string, such as "12.50", so clients do not parse
+// it as a float, and implements sql.Scanner and driver.Valuer for DECIMAL
+// columns.
+type Decimal struct {
+	value *big.Int
+	scale int32 // never negative
+}
+
+var (
+	bigOne = big.NewInt(1)
+	bigTen = big.NewInt(10)
+	pow10s [19]*big.Int
+)
+
+func init() {
+	p := int64(1)
+	for i := range pow10s {
+		pow10s[i] = big.NewInt(p)
+		p *= 10
+	}
+}
+
+func pow10(n int32) *big.Int {
+	if int(n) < len(pow10s) {
+		return pow10s[n]
+	}
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// New returns value * 10^-scale, so New(1250, 2) is 12.50.
+func New(value int64, scale int32) Decimal {
+	return newDecimal(big.NewInt(value), scale)
+}
+
+// NewFromBigInt returns value * 10^-scale. value is copied.
+func NewFromBigInt(value *big.Int, scale int32) Decimal {
+	return newDecimal(new(big.Int).Set(value), scale)
+}
+
+func newDecimal(v *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		v.Mul(v, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{value: v, scale: scale}
+}
+
+// NewFromInt returns i as a Decimal.
+func NewFromInt(i int64) Decimal {
+	return New(i, 0)
+}
+
+// NewFromFloat returns the shortest decimal that converts back to f, so
+// 0.1 gives exactly 0.1 rather than 0.1000000000000000055511151231257827.
+// It panics if f is NaN or infinite.
+func NewFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(fmt.Sprintf("number: cannot convert %v to Decimal", f))
+	}
+	return MustParse(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// maxParseScale bounds the exponent Parse accepts, so "1e999999999"
+// cannot allocate a huge integer.
+const maxParseScale = 1 << 16
+
+// Parse parses a decimal such as "12", "-0.05", "+1.5" or "1.2e-3".
+func Parse(s string) (Decimal, error) {
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("%w %q", ErrInvalidDecimal, s)
+		}
+		exp = e
+	}
+
+	digits, frac := mantissa, 0
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		digits = mantissa[:i] + mantissa[i+1:]
+		frac = len(mantissa) - i - 1
+	}
+	body := strings.TrimLeft(digits, "+-")
+	if len(digits)-len(body) > 1 || body == "" || strings.Trim(body, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("%w %q", ErrInvalidDecimal, s)
+	}
+
+	v, ok := new(big.Int).SetString(digits, 10)
+	scale := int64(frac) - exp
+	if !ok || scale > maxParseScale || scale < -maxParseScale {
+		return Decimal{}, fmt.Errorf("%w %q", ErrInvalidDecimal, s)
+	}
+	return newDecimal(v, int32(scale)), nil
+}
+
+// MustParse is like Parse but panics on error, for constants.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Decimal) val() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+// rescale returns the value of d at the larger scale s.
+func (d Decimal) rescale(s int32) *big.Int {
+	if s == d.scale {
+		return d.val()
+	}
+	return new(big.Int).Mul(d.val(), pow10(s-d.scale))
+}
+
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	s := a.scale
+	if b.scale > s {
+		s = b.scale
+	}
+	return a.rescale(s), b.rescale(s), s
+}
+
+// Add returns d + d2.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	x, y, s := align(d, d2)
+	return Decimal{value: new(big.Int).Add(x, y), scale: s}
+}
+
+// Sub returns d - d2.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	x, y, s := align(d, d2)
+	return Decimal{value: new(big.Int).Sub(x, y), scale: s}
+}
+
+// Mul returns d * d2 with the sum of both scales, so 1.25 * 0.2 is 0.250.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{value: new(big.Int).Mul(d.val(), d2.val()), scale: d.scale + d2.scale}
+}
+
+// Div returns d / d2 rounded to places decimal places with mode.
+func (d Decimal) Div(d2 Decimal, places int32, mode RoundingMode) (Decimal, error) {
+	if d2.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+	if places < 0 {
+		places = 0
+	}
+	// d / d2 * 10^places = dv * 10^(d2.scale - d.scale + places) / d2v
+	num, den := d.val(), d2.val()
+	if e := d2.scale - d.scale + places; e >= 0 {
+		num = new(big.Int).Mul(num, pow10(e))
+	} else {
+		den = new(big.Int).Mul(den, pow10(-e))
+	}
+	return Decimal{value: quo(num, den, mode), scale: places}, nil
+}
+
+// quo returns num / den rounded to an integer with mode.
+func quo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	neg := num.Sign() != den.Sign()
+
+	var away bool
+	switch mode {
+	case RoundDown:
+	case RoundUp:
+		away = true
+	case RoundCeiling:
+		away = !neg
+	case RoundFloor:
+		away = neg
+	default:
+		// compare the remainder with half of the divisor
+		twice := new(big.Int).Abs(r)
+		c := twice.Lsh(twice, 1).CmpAbs(den)
+		away = c > 0 || (c == 0 && (mode == RoundHalfUp || q.Bit(0) == 1))
+	}
+	if away {
+		if neg {
+			q.Sub(q, bigOne)
+		} else {
+			q.Add(q, bigOne)
+		}
+	}
+	return q
+}
+
+// Round returns d rounded to places decimal places with mode.
+// A negative places rounds to tens, hundreds and so on.
+// d is returned unchanged if it has no more than places decimals.
+func (d Decimal) Round(places int32, mode RoundingMode) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	if places >= 0 {
+		return Decimal{value: quo(d.val(), pow10(d.scale-places), mode), scale: places}
+	}
+	q := quo(d.val(), pow10(d.scale-places), mode)
+	return Decimal{value: q.Mul(q, pow10(-places)), scale: 0}
+}
+
+// Truncate returns d with at most places decimal places, dropping the others.
+func (d Decimal) Truncate(places int32) Decimal {
+	return d.Round(places, RoundDown)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.val()), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.val()), scale: d.scale}
+}
+
+// Sign returns -1, 0 or 1 as d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	return d.val().Sign()
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1, 0 or 1 as d is less than, equal to or greater than d2.
+// Scale does not matter, 1.50 equals 1.5.
+func (d Decimal) Cmp(d2 Decimal) int {
+	x, y, _ := align(d, d2)
+	return x.Cmp(y)
+}
+
+// Equal reports whether d and d2 are the same number.
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// LessThan reports whether d < d2.
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+// GreaterThan reports whether d > d2.
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+// Scale returns the number of decimal places d is stored with.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// IntPart returns the integer part of d, truncated toward zero,
+// and whether it fits in an int64.
+func (d Decimal) IntPart() (int64, bool) {
+	v := d.Truncate(0).val()
+	return v.Int64(), v.IsInt64()
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String formats d without exponent, keeping its scale: 12.50 stays "12.50".
+func (d Decimal) String() string {
+	v := d.val()
+	if d.scale == 0 {
+		return v.String()
+	}
+	digits := new(big.Int).Abs(v).String()
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	dot := len(digits) - int(d.scale)
+	s := digits[:dot] + "." + digits[dot:]
+	if v.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// StringFixed formats d rounded half up to exactly places decimal places,
+// so 1.5 with 2 places is "1.50".
+func (d Decimal) StringFixed(places int32) string {
+	if places < 0 {
+		places = 0
+	}
+	r := d.Round(places, RoundHalfUp)
+	if r.scale < places {
+		r = Decimal{value: r.rescale(places), scale: places}
+	}
+	return r.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON encodes d as a JSON string.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON accepts a JSON string or number. null leaves d unchanged.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner for DECIMAL and numeric columns.
+// Use NullDecimal for nullable columns.
+func (d *Decimal) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return d.UnmarshalText(v)
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case int64:
+		*d = NewFromInt(v)
+		return nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("number: cannot scan %v into Decimal", v)
+		}
+		*d = NewFromFloat(v)
+		return nil
+	case float32:
+		if f := float64(v); math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("number: cannot scan %v into Decimal", v)
+		}
+		*d = MustParse(strconv.FormatFloat(float64(v), 'f', -1, 32))
+		return nil
+	case nil:
+		return errors.New("number: cannot scan NULL into Decimal, use NullDecimal")
+	}
+	return fmt.Errorf("number: cannot scan %T into Decimal", src)
+}
+
+// Value implements driver.Valuer, the value is stored as a string so the
+// database converts it without passing through a float.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// NullDecimal is a Decimal that may be NULL in a database
+// or null in JSON.
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
+
+// Scan implements sql.Scanner.
+func (n *NullDecimal) Scan(src any) error {
+	if src == nil {
+		*n = NullDecimal{}
+		return nil
+	}
+	var d Decimal
+	if err := d.Scan(src); err != nil {
+		*n = NullDecimal{}
+		return err
+	}
+	*n = NullDecimal{Decimal: d, Valid: true}
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Decimal.Value()
+}
+
+// MarshalJSON encodes n as a JSON string or null.
+func (n NullDecimal) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Decimal.MarshalJSON()
+}
+
+// UnmarshalJSON accepts a JSON string, number or null.
+func (n *NullDecimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullDecimal{}
+		return nil
+	}
+	var d Decimal
+	if err := d.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*n = NullDecimal{Decimal: d, Valid: true}
+	return nil
+}
@@ -0,0 +1,219 @@
+package number
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestDecimalParseString(t *testing.T) {
+	cases := map[string]string{
+		"12":       "12",
+		"-0.05":    "-0.05",
+		"+1.50":    "1.50",
+		".5":       "0.5",
+		"1.2e-3":   "0.0012",
+		"1.2E3":    "1200",
+		"-0":       "0",
+		"0.000000": "0.000000",
+		"123456789012345678901234567890.123456789": "123456789012345678901234567890.123456789",
+	}
+	for in, want := range cases {
+		d, err := Parse(in)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", in, err)
+			continue
+		}
+		if d.String() != want {
+			t.Errorf("Parse(%q) = %s, want %s", in, d, want)
+		}
+	}
+	for _, in := range []string{"", "-", "abc", "1.2.3", "1e", "--1", "1-2", "1e999999999", " 1"} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalidDecimal) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidDecimal", in, err)
+		}
+	}
+	if got := New(1250, 2).String(); got != "12.50" {
+		t.Errorf("New(1250, 2) = %s", got)
+	}
+	if got := New(-5, 3).String(); got != "-0.005" {
+		t.Errorf("New(-5, 3) = %s", got)
+	}
+	if got := New(12, -2).String(); got != "1200" {
+		t.Errorf("New(12, -2) = %s", got)
+	}
+	if got := NewFromBigInt(big.NewInt(7), 1).String(); got != "0.7" {
+		t.Errorf("NewFromBigInt = %s", got)
+	}
+	var zero Decimal
+	if zero.String() != "0" || !zero.IsZero() {
+		t.Errorf("zero value = %s", zero)
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := NewFromFloat(0.1), NewFromFloat(0.2)
+	if got := a.Add(b); got.String() != "0.3" || !got.Equal(MustParse("0.30")) {
+		t.Errorf("0.1 + 0.2 = %s", got)
+	}
+	if got := MustParse("10.00").Sub(MustParse("0.015")); got.String() != "9.985" {
+		t.Errorf("10.00 - 0.015 = %s", got)
+	}
+	if got := MustParse("1.25").Mul(MustParse("-0.2")); got.String() != "-0.250" {
+		t.Errorf("1.25 * -0.2 = %s", got)
+	}
+	if got := MustParse("-3.7").Abs().Neg(); got.String() != "-3.7" {
+		t.Errorf("Abs/Neg = %s", got)
+	}
+	if MustParse("1.5").Cmp(MustParse("1.50")) != 0 || !MustParse("-1").LessThan(New(1, 3)) || !New(2, 0).GreaterThan(MustParse("1.999")) {
+		t.Error("comparison")
+	}
+	if i, ok := MustParse("-12.9").IntPart(); !ok || i != -12 {
+		t.Errorf("IntPart = %d, %v", i, ok)
+	}
+	if f := MustParse("12.5").Float64(); f != 12.5 {
+		t.Errorf("Float64 = %v", f)
+	}
+}
+
+func TestDecimalDiv(t *testing.T) {
+	cases := []struct {
+		a, b   string
+		places int32
+		mode   RoundingMode
+		want   string
+	}{
+		{"10", "3", 2, RoundHalfUp, "3.33"},
+		{"20", "3", 2, RoundHalfUp, "6.67"},
+		{"20", "3", 2, RoundDown, "6.66"},
+		{"-20", "3", 2, RoundDown, "-6.66"},
+		{"-20", "3", 2, RoundCeiling, "-6.66"},
+		{"-20", "3", 2, RoundFloor, "-6.67"},
+		{"10", "3", 2, RoundCeiling, "3.34"},
+		{"10", "3", 2, RoundUp, "3.34"},
+		{"1", "8", 2, RoundHalfEven, "0.12"},
+		{"3", "8", 2, RoundHalfEven, "0.38"},
+		{"1", "8", 2, RoundHalfUp, "0.13"},
+		{"-1", "8", 2, RoundHalfUp, "-0.13"},
+		{"1.5", "0.25", 0, RoundHalfUp, "6"},
+		{"100", "0.03", 3, RoundHalfUp, "3333.333"},
+		{"0.001", "1000", 2, RoundHalfUp, "0.00"},
+	}
+	for _, c := range cases {
+		got, err := MustParse(c.a).Div(MustParse(c.b), c.places, c.mode)
+		if err != nil || got.String() != c.want {
+			t.Errorf("%s / %s (%d, mode %d) = %s, %v, want %s", c.a, c.b, c.places, c.mode, got, err, c.want)
+		}
+	}
+	if _, err := NewFromInt(1).Div(MustParse("0.00"), 2, RoundHalfUp); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("division by zero error = %v", err)
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	cases := []struct {
+		in     string
+		places int32
+		mode   RoundingMode
+		want   string
+	}{
+		{"2.5", 0, RoundHalfUp, "3"},
+		{"-2.5", 0, RoundHalfUp, "-3"},
+		{"2.5", 0, RoundHalfEven, "2"},
+		{"3.5", 0, RoundHalfEven, "4"},
+		{"2.51", 0, RoundHalfEven, "3"},
+		{"2.9", 0, RoundDown, "2"},
+		{"-2.9", 0, RoundCeiling, "-2"},
+		{"-2.1", 0, RoundFloor, "-3"},
+		{"1.005", 2, RoundHalfUp, "1.01"},
+		{"1.2", 3, RoundHalfUp, "1.2"},
+		{"1250", -2, RoundHalfEven, "1200"},
+		{"1351", -2, RoundHalfUp, "1400"},
+	}
+	for _, c := range cases {
+		if got := MustParse(c.in).Round(c.places, c.mode); got.String() != c.want {
+			t.Errorf("Round(%s, %d, mode %d) = %s, want %s", c.in, c.places, c.mode, got, c.want)
+		}
+	}
+	if got := MustParse("9.999").Truncate(2).String(); got != "9.99" {
+		t.Errorf("Truncate = %s", got)
+	}
+	if got := MustParse("1.5").StringFixed(3); got != "1.500" {
+		t.Errorf("StringFixed(3) = %s", got)
+	}
+	if got := MustParse("1.005").StringFixed(2); got != "1.01" {
+		t.Errorf("StringFixed(2) = %s", got)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	type order struct {
+		Amount Decimal     `json:"amount"`
+		Refund NullDecimal `json:"refund"`
+	}
+	o := order{Amount: MustParse("19.90")}
+	data, err := json.Marshal(o)
+	if err != nil || string(data) != `{"amount":"19.90","refund":null}` {
+		t.Fatalf("Marshal = %s, %v", data, err)
+	}
+
+	var got order
+	if err := json.Unmarshal([]byte(`{"amount":19.9,"refund":"0.10"}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Amount.String() != "19.9" || !got.Refund.Valid || got.Refund.Decimal.String() != "0.10" {
+		t.Errorf("Unmarshal = %+v", got)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"x"}`), &got); err == nil {
+		t.Error("Unmarshal should reject an invalid amount")
+	}
+}
+
+func TestDecimalSQL(t *testing.T) {
+	var d Decimal
+	for _, src := range []any{[]byte("12.34"), "12.34", 12.34, float32(12.34)} {
+		if err := d.Scan(src); err != nil || d.String() != "12.34" {
+			t.Errorf("Scan(%T) = %s, %v", src, d, err)
+		}
+	}
+	if err := d.Scan(int64(7)); err != nil || d.String() != "7" {
+		t.Errorf("Scan(int64) = %s, %v", d, err)
+	}
+	if err := d.Scan(nil); err == nil {
+		t.Error("Scan(nil) should fail")
+	}
+	for _, src := range []any{math.NaN(), math.Inf(1), float32(math.Inf(-1))} {
+		if err := d.Scan(src); err == nil {
+			t.Errorf("Scan(%v) should fail", src)
+		}
+	}
+	if v, _ := MustParse("-0.50").Value(); v != "-0.50" {
+		t.Errorf("Value() = %v", v)
+	}
+
+	var n NullDecimal
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("NullDecimal.Scan(nil) = %+v, %v", n, err)
+	}
+	if v, _ := n.Value(); v != nil {
+		t.Errorf("NullDecimal.Value() = %v", v)
+	}
+	if err := n.Scan("1.5"); err != nil || !n.Valid || n.Decimal.String() != "1.5" {
+		t.Errorf("NullDecimal.Scan = %+v, %v", n, err)
+	}
+	if err := n.Scan("abc"); err == nil || n.Valid {
+		t.Errorf("NullDecimal.Scan(abc) = %+v, %v", n, err)
+	}
+	if err := n.UnmarshalJSON([]byte(`"abc"`)); err == nil || n.Valid {
+		t.Errorf("NullDecimal.UnmarshalJSON(abc) = %+v, %v", n, err)
+	}
+}
+
+func BenchmarkDecimalAdd(b *testing.B) {
+	x, y := MustParse("12345.6789"), MustParse("0.01")
+	for i := 0; i < b.N; i++ {
+		x = x.Add(y)
+	}
+}
@@ -0,0 +1,139 @@
+package number
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Day and Week extend the units of time.Duration.
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// Duration is a time.Duration that also accepts days and weeks, such as
+// "7d", "1w2d" or "1d12h30m". It implements flag.Value,
+// encoding.TextMarshaler, encoding.TextUnmarshaler and JSON, which also
+// accepts a plain number of nanoseconds.
+type Duration time.Duration
+
+// ParseDuration parses a duration like time.ParseDuration with the extra
+// units d (24h) and w (7d): "1d12h", "2w", "-1.5d".
+func ParseDuration(s string) (time.Duration, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", orig)
+	}
+
+	var d time.Duration
+	for s != "" {
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+			i++
+		}
+		j := i
+		for j < len(s) && !(s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+			j++
+		}
+		num, unit := s[:i], s[i:j]
+		if num == "" || unit == "" {
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+
+		var part time.Duration
+		switch unit {
+		case "d", "w":
+			mult := Day
+			if unit == "w" {
+				mult = Week
+			}
+			v, ok := scaleInt(num, int64(mult))
+			if !ok {
+				return 0, fmt.Errorf("invalid duration %q", orig)
+			}
+			part = time.Duration(v)
+		default:
+			v, err := time.ParseDuration(num + unit)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", orig)
+			}
+			part = v
+		}
+		if d+part < d {
+			return 0, fmt.Errorf("invalid duration %q: overflow", orig)
+		}
+		d += part
+		s = s[j:]
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// Std returns d as a time.Duration.
+func (d Duration) Std() time.Duration {
+	return time.Duration(d)
+}
+
+// String formats d like time.Duration with whole days in front:
+// 36h is "1d12h0m0s" and 48h "2d".
+func (d Duration) String() string {
+	td := time.Duration(d)
+	days := td / Day
+	if days == 0 {
+		return td.String()
+	}
+	rest := td % Day
+	if rest < 0 {
+		rest = -rest
+	}
+	s := fmt.Sprintf("%dd", days)
+	if rest != 0 {
+		s += rest.String()
+	}
+	return s
+}
+
+// Set implements flag.Value.
+func (d *Duration) Set(s string) error {
+	v, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	return d.Set(string(text))
+}
+
+// UnmarshalJSON accepts a duration string or a number of nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '"' && string(data) != "null" {
+		v, ok := scaleInt(string(data), 1)
+		if !ok {
+			return fmt.Errorf("invalid duration %s", data)
+		}
+		*d = Duration(v)
+		return nil
+	}
+	return unmarshalJSONText(data, d)
+}
@@ -0,0 +1,70 @@
+package number
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		s    string
+		want time.Duration
+	}{
+		{"0", 0},
+		{"90s", 90 * time.Second},
+		{"7d", 7 * Day},
+		{"1w2d", 9 * Day},
+		{"1d12h30m", Day + 12*time.Hour + 30*time.Minute},
+		{"-1.5d", -36 * time.Hour},
+		{"1d500ms", Day + 500*time.Millisecond},
+	}
+	for _, c := range cases {
+		if got, err := ParseDuration(c.s); err != nil || got != c.want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", c.s, got, err, c.want)
+		}
+	}
+	for _, s := range []string{"", "d", "1x", "1d-2h", "100000000w"} {
+		if _, err := ParseDuration(s); err == nil {
+			t.Errorf("ParseDuration(%q) should fail", s)
+		}
+	}
+}
+
+func TestDurationFormat(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{90 * time.Second, "1m30s"},
+		{2 * Day, "2d"},
+		{36 * time.Hour, "1d12h0m0s"},
+		{-36 * time.Hour, "-1d12h0m0s"},
+	}
+	for _, c := range cases {
+		d := Duration(c.d)
+		if got := d.String(); got != c.want {
+			t.Errorf("Duration(%v).String() = %q, want %q", c.d, got, c.want)
+		}
+		if back, err := ParseDuration(d.String()); err != nil || back != c.d {
+			t.Errorf("round trip of %v = %v, %v", c.d, back, err)
+		}
+	}
+}
+
+func TestDurationJSON(t *testing.T) {
+	var cfg struct {
+		TTL     Duration `json:"ttl"`
+		Timeout Duration `json:"timeout"`
+	}
+	if err := json.Unmarshal([]byte(`{"ttl":"30d","timeout":1000000000}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TTL.Std() != 30*Day || cfg.Timeout.Std() != time.Second {
+		t.Errorf("Unmarshal = %+v", cfg)
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil || string(data) != `{"ttl":"30d","timeout":"1s"}` {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+}
@@ -1 +1,204 @@
 package number
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Round returns x rounded to places decimal places with mode. It rounds the
+// shortest decimal representation of x, the one strconv prints, rather than
+// its binary value, so Round(1.005, 2, RoundHalfUp) is 1.01 and not 1.0
+// as math.Round(1.005*100)/100 gives. A negative places rounds to tens,
+// hundreds and so on. NaN and infinities are returned as is.
+func Round(x float64, places int, mode RoundingMode) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) || x == 0 {
+		return x
+	}
+	return NewFromFloat(x).Round(int32(places), mode).Float64()
+}
+
+// Truncate returns x with at most places decimal places, dropping the
+// others toward zero: Truncate(2.789, 2) is 2.78.
+func Truncate(x float64, places int) float64 {
+	return Round(x, places, RoundDown)
+}
+
+// DefaultEpsilon is the tolerance of AlmostEqual.
+const DefaultEpsilon = 1e-9
+
+// AlmostEqual reports whether a and b are equal within DefaultEpsilon,
+// absolute near zero and relative to their magnitude elsewhere, so
+// 0.1+0.2 equals 0.3 and 1e20+1e5 equals 1e20.
+func AlmostEqual(a, b float64) bool {
+	return AlmostEqualAbs(a, b, DefaultEpsilon) || AlmostEqualRel(a, b, DefaultEpsilon)
+}
+
+// AlmostEqualAbs reports whether |a-b| <= eps.
+func AlmostEqualAbs(a, b, eps float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= eps
+}
+
+// AlmostEqualRel reports whether |a-b| <= rel * max(|a|, |b|).
+func AlmostEqualRel(a, b, rel float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= rel*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// AlmostEqualULP reports whether a and b are at most ulps representable
+// float64 apart. +0 and -0 are equal, NaN equals nothing.
+func AlmostEqualULP(a, b float64, ulps uint64) bool {
+	if a == b {
+		return true
+	}
+	if math.IsNaN(a) || math.IsNaN(b) || math.Signbit(a) != math.Signbit(b) {
+		return false
+	}
+	ia, ib := math.Float64bits(a), math.Float64bits(b)
+	if ia > ib {
+		ia, ib = ib, ia
+	}
+	return ib-ia <= ulps
+}
+
+// FormatFixed formats x with exactly places decimal places, rounding half
+// up as Round does: FormatFixed(1.005, 2) is "1.01", FormatFixed(2, 2) "2.00".
+func FormatFixed(x float64, places int) string {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	if places < 0 {
+		places = 0
+	}
+	return NewFromFloat(x).StringFixed(int32(places))
+}
+
+// FormatSignificant formats x with digits significant digits and without
+// exponent: FormatSignificant(123456, 3) is "123000" and
+// FormatSignificant(0.00012345, 2) "0.00012".
+func FormatSignificant(x float64, digits int) string {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	if digits < 1 {
+		digits = 1
+	}
+	s := strconv.FormatFloat(x, 'e', digits-1, 64)
+	return MustParse(s).String()
+}
+
+// FormatThousands formats x with places decimal places, or as few as
+// needed if places is negative, and a comma between groups of three
+// digits: FormatThousands(1234567.891, 2) is "1,234,567.89".
+func FormatThousands(x float64, places int) string {
+	return FormatGrouped(x, places, ",")
+}
+
+// FormatGrouped is like FormatThousands with sep between groups,
+// such as " " or "'".
+func FormatGrouped(x float64, places int, sep string) string {
+	var s string
+	if places < 0 {
+		s = strconv.FormatFloat(x, 'f', -1, 64)
+	} else {
+		s = FormatFixed(x, places)
+	}
+	return groupDigits(s, sep)
+}
+
+// groupDigits inserts sep between groups of three digits in the integer
+// part of the formatted number s.
+func groupDigits(s, sep string) string {
+	sign := ""
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+	if len(intPart) <= 3 || strings.Trim(intPart, "0123456789") != "" {
+		return sign + s
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(sign) + len(s) + len(intPart)/3*len(sep))
+	sb.WriteString(sign)
+	head := len(intPart) % 3
+	if head == 0 {
+		head = 3
+	}
+	sb.WriteString(intPart[:head])
+	for i := head; i < len(intPart); i += 3 {
+		sb.WriteString(sep)
+		sb.WriteString(intPart[i : i+3])
+	}
+	sb.WriteString(frac)
+	return sb.String()
+}
+
+// FormatPercent formats the ratio x as a percentage with places decimal
+// places: FormatPercent(0.12345, 1) is "12.3%".
+func FormatPercent(x float64, places int) string {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return strconv.FormatFloat(x, 'f', -1, 64) + "%"
+	}
+	return FormatFixed(NewFromFloat(x).Mul(New(100, 0)).Float64(), places) + "%"
+}
+
+type unit struct {
+	value  float64
+	suffix string
+}
+
+var (
+	compactUnits = []unit{{1e12, "T"}, {1e9, "B"}, {1e6, "M"}, {1e3, "K"}}
+	chineseUnits = []unit{{1e12, "万亿"}, {1e8, "亿"}, {1e4, "万"}}
+)
+
+// Humanize formats x in a compact form with at most places decimal places
+// and K, M, B, T suffixes: 1234 is "1.2K" and 3400000 "3.4M" with one
+// place. Values below 1000 are only rounded.
+func Humanize(x float64, places int) string {
+	return humanize(x, places, compactUnits)
+}
+
+// HumanizeCN is like Humanize with the Chinese units 万, 亿 and 万亿:
+// 12345 is "1.2万" and 340000000 "3.4亿" with one place.
+func HumanizeCN(x float64, places int) string {
+	return humanize(x, places, chineseUnits)
+}
+
+func humanize(x float64, places int, units []unit) string {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	if places < 0 {
+		places = 0
+	}
+	abs := math.Abs(x)
+	for i, u := range units {
+		if abs < u.value {
+			continue
+		}
+		v := Round(x/u.value, places, RoundHalfUp)
+		// 999950 rounds to 1000.0K, move it to the larger unit
+		if i > 0 && math.Abs(v)*u.value >= units[i-1].value {
+			u = units[i-1]
+			v = Round(x/u.value, places, RoundHalfUp)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64) + u.suffix
+	}
+
+	v := Round(x, places, RoundHalfUp)
+	last := units[len(units)-1]
+	if math.Abs(v) >= last.value {
+		return strconv.FormatFloat(Round(x/last.value, places, RoundHalfUp), 'f', -1, 64) + last.suffix
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,122 @@
+package number
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	cases := []struct {
+		x      float64
+		places int
+		mode   RoundingMode
+		want   float64
+	}{
+		{1.005, 2, RoundHalfUp, 1.01},
+		{2.675, 2, RoundHalfUp, 2.68},
+		{-2.5, 0, RoundHalfUp, -3},
+		{2.5, 0, RoundHalfEven, 2},
+		{0.125, 2, RoundHalfEven, 0.12},
+		{2.789, 2, RoundDown, 2.78},
+		{-2.781, 2, RoundFloor, -2.79},
+		{2.781, 2, RoundCeiling, 2.79},
+		{1234.5, -2, RoundHalfUp, 1200},
+		{3, 2, RoundHalfUp, 3},
+	}
+	for _, c := range cases {
+		if got := Round(c.x, c.places, c.mode); got != c.want {
+			t.Errorf("Round(%v, %d, %d) = %v, want %v", c.x, c.places, c.mode, got, c.want)
+		}
+	}
+	if Truncate(-9.999, 1) != -9.9 {
+		t.Errorf("Truncate = %v", Truncate(-9.999, 1))
+	}
+	if !math.IsNaN(Round(math.NaN(), 2, RoundHalfUp)) || !math.IsInf(Round(math.Inf(1), 2, RoundHalfUp), 1) {
+		t.Error("NaN and Inf should be returned as is")
+	}
+}
+
+func TestAlmostEqual(t *testing.T) {
+	if !AlmostEqual(0.1+0.2, 0.3) || !AlmostEqual(1e20+1e5, 1e20) || AlmostEqual(1, 1.001) {
+		t.Error("AlmostEqual")
+	}
+	if !AlmostEqualAbs(1, 1.05, 0.1) || AlmostEqualAbs(1, 1.2, 0.1) {
+		t.Error("AlmostEqualAbs")
+	}
+	if !AlmostEqualRel(100, 101, 0.01) || AlmostEqualRel(100, 102, 0.01) {
+		t.Error("AlmostEqualRel")
+	}
+	next := math.Nextafter(1, 2)
+	if !AlmostEqualULP(1, next, 1) || AlmostEqualULP(1, math.Nextafter(next, 2), 1) {
+		t.Error("AlmostEqualULP distance")
+	}
+	if !AlmostEqualULP(0, math.Copysign(0, -1), 0) || AlmostEqualULP(math.NaN(), math.NaN(), 10) || AlmostEqualULP(-1e-300, 1e-300, 1<<62) {
+		t.Error("AlmostEqualULP special values")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		got, want string
+	}{
+		{FormatFixed(1.005, 2), "1.01"},
+		{FormatFixed(2, 2), "2.00"},
+		{FormatFixed(-0.5, 0), "-1"},
+		{FormatSignificant(123456, 3), "123000"},
+		{FormatSignificant(0.00012345, 2), "0.00012"},
+		{FormatSignificant(-9.996, 3), "-10.0"},
+		{FormatThousands(1234567.891, 2), "1,234,567.89"},
+		{FormatThousands(-1234567, -1), "-1,234,567"},
+		{FormatThousands(999.5, 0), "1,000"},
+		{FormatThousands(123, 1), "123.0"},
+		{FormatGrouped(1e6, -1, " "), "1 000 000"},
+		{FormatPercent(0.12345, 1), "12.3%"},
+		{FormatPercent(0.07, 2), "7.00%"},
+		{FormatPercent(1.5, 0), "150%"},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("got %q, want %q", c.got, c.want)
+		}
+	}
+}
+
+func TestHumanize(t *testing.T) {
+	cases := []struct {
+		x      float64
+		places int
+		want   string
+	}{
+		{0, 1, "0"},
+		{999, 1, "999"},
+		{999.96, 1, "1K"},
+		{1234, 1, "1.2K"},
+		{1000, 1, "1K"},
+		{3400000, 1, "3.4M"},
+		{999950, 1, "1M"},
+		{-2500000000, 2, "-2.5B"},
+		{7.8e12, 1, "7.8T"},
+	}
+	for _, c := range cases {
+		if got := Humanize(c.x, c.places); got != c.want {
+			t.Errorf("Humanize(%v, %d) = %q, want %q", c.x, c.places, got, c.want)
+		}
+	}
+
+	cn := []struct {
+		x      float64
+		places int
+		want   string
+	}{
+		{9999, 1, "9999"},
+		{12345, 1, "1.2万"},
+		{340000000, 1, "3.4亿"},
+		{99999999, 1, "1亿"},
+		{1.5e12, 2, "1.5万亿"},
+	}
+	for _, c := range cn {
+		if got := HumanizeCN(c.x, c.places); got != c.want {
+			t.Errorf("HumanizeCN(%v, %d) = %q, want %q", c.x, c.places, got, c.want)
+		}
+	}
+}
@@ -1 +1,179 @@
 package number
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+
+	"github.com/hy-shine/gotiny/cal"
+)
+
+var (
+	// ErrOverflow is returned when an integer does not fit its target type.
+	// It is cal.ErrOverflow, so one errors.Is check covers both packages.
+	ErrOverflow = cal.ErrOverflow
+	// ErrInvalidNumber is returned when parsing a malformed number.
+	ErrInvalidNumber = errors.New("invalid number")
+)
+
+// SafeCast converts v to the type To, reporting ErrOverflow instead of
+// silently wrapping when v is out of its range: SafeCast[uint8](300) and
+// SafeCast[uint](-1) both fail.
+func SafeCast[To, From constraints.Integer](v From) (To, error) {
+	t := To(v)
+	if From(t) != v || (v < 0) != (t < 0) {
+		return 0, fmt.Errorf("%w: %v does not fit %T", ErrOverflow, v, t)
+	}
+	return t, nil
+}
+
+// MustCast is like SafeCast but panics on overflow.
+func MustCast[To, From constraints.Integer](v From) To {
+	t, err := SafeCast[To](v)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Alphabets of the base encodings, in digit order.
+const (
+	Base36Alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	Base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// FormatBase formats v with the digits of alphabet, whose length is the
+// base: FormatBase(61, Base62Alphabet) is "z". It panics if alphabet has
+// fewer than 2 bytes.
+func FormatBase(v uint64, alphabet string) string {
+	base := uint64(len(alphabet))
+	if base < 2 {
+		panic("number: alphabet needs at least 2 digits")
+	}
+	if v == 0 {
+		return alphabet[:1]
+	}
+	var buf [64]byte
+	i := len(buf)
+	for v > 0 {
+		i--
+		buf[i] = alphabet[v%base]
+		v /= base
+	}
+	return string(buf[i:])
+}
+
+// ParseBase parses s written with the digits of alphabet, the inverse of
+// FormatBase. It returns ErrInvalidNumber for an unknown digit and
+// ErrOverflow if the value does not fit an uint64.
+func ParseBase(s, alphabet string) (uint64, error) {
+	base := uint64(len(alphabet))
+	if base < 2 {
+		panic("number: alphabet needs at least 2 digits")
+	}
+	if s == "" {
+		return 0, fmt.Errorf("%w %q", ErrInvalidNumber, s)
+	}
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(alphabet, s[i])
+		if d < 0 {
+			return 0, fmt.Errorf("%w %q", ErrInvalidNumber, s)
+		}
+		next := v*base + uint64(d)
+		if v > (1<<64-1)/base || next < v*base {
+			return 0, fmt.Errorf("%w: %q", ErrOverflow, s)
+		}
+		v = next
+	}
+	return v, nil
+}
+
+// FormatBase36 formats v in base 36 with lower-case letters, as
+// strconv.FormatUint(v, 36) does.
+func FormatBase36(v uint64) string {
+	return FormatBase(v, Base36Alphabet)
+}
+
+// ParseBase36 parses a base 36 number, ignoring the case of letters.
+func ParseBase36(s string) (uint64, error) {
+	return ParseBase(strings.ToLower(s), Base36Alphabet)
+}
+
+// FormatBase62 formats v in base 62, digits then upper then lower case
+// letters, for short ids in URLs.
+func FormatBase62(v uint64) string {
+	return FormatBase(v, Base62Alphabet)
+}
+
+// ParseBase62 parses a base 62 number, the inverse of FormatBase62.
+func ParseBase62(s string) (uint64, error) {
+	return ParseBase(s, Base62Alphabet)
+}
+
+// abs returns the magnitude of v as an uint64, which holds the one of
+// the smallest int64 too.
+func abs[T constraints.Integer](v T) uint64 {
+	if v < 0 {
+		return uint64(-int64(v))
+	}
+	return uint64(v)
+}
+
+// DigitCount returns the number of decimal digits of v, 1 for 0.
+func DigitCount[T constraints.Integer](v T) int {
+	n, u := 1, abs(v)
+	for ; u >= 10; u /= 10 {
+		n++
+	}
+	return n
+}
+
+// DigitSum returns the sum of the decimal digits of v, ignoring its sign.
+func DigitSum[T constraints.Integer](v T) int {
+	sum, u := 0, abs(v)
+	for ; u > 0; u /= 10 {
+		sum += int(u % 10)
+	}
+	return sum
+}
+
+// ReverseDigits returns v with its decimal digits reversed, keeping its
+// sign: 1230 gives 321 and -12 gives -21. It returns ErrOverflow when the
+// result does not fit T, as reversing int32 1000000009 does.
+func ReverseDigits[T constraints.Integer](v T) (T, error) {
+	var r uint64
+	for u := abs(v); u > 0; u /= 10 {
+		if r > (1<<64-1)/10 {
+			return 0, fmt.Errorf("%w: reverse of %v", ErrOverflow, v)
+		}
+		r = r*10 + u%10
+	}
+	if v < 0 {
+		if r > 1<<63 {
+			return 0, fmt.Errorf("%w: reverse of %v", ErrOverflow, v)
+		}
+		return SafeCast[T](-int64(r))
+	}
+	return SafeCast[T](r)
+}
+
+// IsPalindrome reports whether the decimal digits of v read the same
+// both ways. Negative numbers are not palindromes.
+func IsPalindrome[T constraints.Integer](v T) bool {
+	if v < 0 {
+		return false
+	}
+	u := uint64(v)
+	var r uint64
+	for n := u; n > 0; n /= 10 {
+		// a palindrome never overflows, its reverse is itself
+		if r > (1<<64-1)/10 {
+			return false
+		}
+		r = r*10 + n%10
+	}
+	return r == u
+}
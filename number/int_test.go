@@ -0,0 +1,99 @@
+package number
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/hy-shine/gotiny/cal"
+)
+
+func TestSafeCast(t *testing.T) {
+	if v, err := SafeCast[uint8](255); err != nil || v != 255 {
+		t.Errorf("SafeCast[uint8](255) = %v, %v", v, err)
+	}
+	if v, err := SafeCast[int8](int64(-128)); err != nil || v != -128 {
+		t.Errorf("SafeCast[int8](-128) = %v, %v", v, err)
+	}
+	if v, err := SafeCast[int64](uint64(math.MaxInt64)); err != nil || v != math.MaxInt64 {
+		t.Errorf("SafeCast[int64](MaxInt64) = %v, %v", v, err)
+	}
+	if _, err := SafeCast[uint8](300); !errors.Is(err, ErrOverflow) || !errors.Is(err, cal.ErrOverflow) {
+		t.Errorf("SafeCast[uint8](300) error = %v", err)
+	}
+	if _, err := SafeCast[uint](-1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("SafeCast[uint](-1) error = %v", err)
+	}
+	if _, err := SafeCast[int64](uint64(math.MaxUint64)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("SafeCast[int64](MaxUint64) error = %v", err)
+	}
+	if _, err := SafeCast[int32](int64(math.MinInt32 - 1)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("SafeCast[int32](MinInt32-1) error = %v", err)
+	}
+}
+
+func TestBase(t *testing.T) {
+	for _, v := range []uint64{0, 1, 35, 36, 61, 62, 123456789, math.MaxUint64} {
+		if got := FormatBase36(v); got != strconv.FormatUint(v, 36) {
+			t.Errorf("FormatBase36(%d) = %q", v, got)
+		}
+		if back, err := ParseBase36(FormatBase36(v)); err != nil || back != v {
+			t.Errorf("ParseBase36 round trip of %d = %d, %v", v, back, err)
+		}
+		if back, err := ParseBase62(FormatBase62(v)); err != nil || back != v {
+			t.Errorf("ParseBase62 round trip of %d = %d, %v", v, back, err)
+		}
+	}
+	if got := FormatBase62(61); got != "z" {
+		t.Errorf("FormatBase62(61) = %q", got)
+	}
+	if got := FormatBase(5, "01"); got != "101" {
+		t.Errorf("FormatBase(5, binary) = %q", got)
+	}
+	if v, err := ParseBase36("ZZ"); err != nil || v != 36*36-1 {
+		t.Errorf("ParseBase36(ZZ) = %d, %v", v, err)
+	}
+	if _, err := ParseBase62("a-b"); !errors.Is(err, ErrInvalidNumber) {
+		t.Errorf("ParseBase62(a-b) error = %v", err)
+	}
+	if _, err := ParseBase62(""); !errors.Is(err, ErrInvalidNumber) {
+		t.Errorf("ParseBase62(\"\") error = %v", err)
+	}
+	if _, err := ParseBase62("zzzzzzzzzzzz"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ParseBase62 overflow error = %v", err)
+	}
+}
+
+func TestDigits(t *testing.T) {
+	if DigitCount(0) != 1 || DigitCount(-12345) != 5 || DigitCount(uint64(math.MaxUint64)) != 20 {
+		t.Error("DigitCount")
+	}
+	if DigitSum(0) != 0 || DigitSum(-987) != 24 || DigitSum(int64(math.MinInt64)) != 89 {
+		t.Errorf("DigitSum(MinInt64) = %d", DigitSum(int64(math.MinInt64)))
+	}
+
+	if v, err := ReverseDigits(1230); err != nil || v != 321 {
+		t.Errorf("ReverseDigits(1230) = %d, %v", v, err)
+	}
+	if v, err := ReverseDigits(int8(-12)); err != nil || v != -21 {
+		t.Errorf("ReverseDigits(-12) = %d, %v", v, err)
+	}
+	if _, err := ReverseDigits(int32(1000000009)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ReverseDigits(1000000009) error = %v", err)
+	}
+	if _, err := ReverseDigits(uint64(math.MaxUint64)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ReverseDigits(MaxUint64) error = %v", err)
+	}
+
+	for _, v := range []int{0, 7, 121, 1221, 12321} {
+		if !IsPalindrome(v) {
+			t.Errorf("IsPalindrome(%d) = false", v)
+		}
+	}
+	for _, v := range []int{10, 123, -121} {
+		if IsPalindrome(v) {
+			t.Errorf("IsPalindrome(%d) = true", v)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package number
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+var romanNumerals = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// FormatRoman formats n as a Roman numeral: 1994 is "MCMXCIV". Only 1 to
+// 3999 can be written, other values return ErrOverflow.
+func FormatRoman(n int) (string, error) {
+	if n < 1 || n > 3999 {
+		return "", fmt.Errorf("%w: %d is not in the roman numeral range 1-3999", ErrOverflow, n)
+	}
+	var sb strings.Builder
+	for _, r := range romanNumerals {
+		for ; n >= r.value; n -= r.value {
+			sb.WriteString(r.symbol)
+		}
+	}
+	return sb.String(), nil
+}
+
+// ParseRoman parses a Roman numeral in either case. It only accepts the
+// canonical form FormatRoman returns, so "IIII" and "IC" are invalid.
+func ParseRoman(s string) (int, error) {
+	upper := strings.ToUpper(s)
+	n, rest := 0, upper
+	for _, r := range romanNumerals {
+		for strings.HasPrefix(rest, r.symbol) {
+			n += r.value
+			rest = rest[len(r.symbol):]
+		}
+	}
+	if rest != "" || n == 0 {
+		return 0, fmt.Errorf("%w: roman numeral %q", ErrInvalidNumber, s)
+	}
+	if canonical, _ := FormatRoman(n); canonical != upper {
+		return 0, fmt.Errorf("%w: roman numeral %q", ErrInvalidNumber, s)
+	}
+	return n, nil
+}
+
+const chineseDigits = "零一二三四五六七八九"
+
+var chineseSmallUnits = [...]string{"", "十", "百", "千"}
+
+// FormatChinese writes v in Chinese numerals: 123 is "一百二十三", 10005
+// "一万零五", 15 "十五" and -2 "负二". Units go 万, 亿, 万亿 then 亿亿.
+func FormatChinese[T constraints.Integer](v T) string {
+	if v == 0 {
+		return "零"
+	}
+	s := formatChinese(abs(v), []rune(chineseDigits), chineseSmallUnits[:], "亿", "万")
+	if strings.HasPrefix(s, "一十") {
+		s = strings.TrimPrefix(s, "一")
+	}
+	if v < 0 {
+		s = "负" + s
+	}
+	return s
+}
+
+// formatChinese writes u > 0 with the given digits and units, splitting on
+// 亿亿, 亿 then 万 so the smaller units compose, as in 一万五千亿.
+func formatChinese(u uint64, digits []rune, small []string, yi, wan string) string {
+	for _, big := range []struct {
+		value uint64
+		unit  string
+	}{{1e16, yi + yi}, {1e8, yi}, {1e4, wan}} {
+		if u < big.value {
+			continue
+		}
+		s := formatChinese(u/big.value, digits, small, yi, wan) + big.unit
+		if r := u % big.value; r > 0 {
+			if r < big.value/10 {
+				s += string(digits[0])
+			}
+			s += formatChinese(r, digits, small, yi, wan)
+		}
+		return s
+	}
+
+	// u < 10000, write its digits with one zero for each run of zeros
+	var sb strings.Builder
+	zero := false
+	for i, div := 3, uint64(1000); i >= 0; i, div = i-1, div/10 {
+		d := u / div % 10
+		if d == 0 {
+			zero = sb.Len() > 0
+			continue
+		}
+		if zero {
+			sb.WriteRune(digits[0])
+			zero = false
+		}
+		sb.WriteRune(digits[d])
+		sb.WriteString(small[i])
+	}
+	return sb.String()
+}
+
+// chineseDigitValues maps the digits accepted by ParseChinese, lower and
+// upper case, to their value.
+var chineseDigitValues = map[rune]uint64{
+	'零': 0, '〇': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4,
+	'五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+	'壹': 1, '贰': 2, '叁': 3, '肆': 4, '伍': 5, '陆': 6, '柒': 7, '捌': 8, '玖': 9,
+}
+
+var chineseUnitValues = map[rune]uint64{
+	'十': 10, '百': 100, '千': 1000, '拾': 10, '佰': 100, '仟': 1000,
+}
+
+// ParseChinese parses a number in Chinese numerals, the inverse of
+// FormatChinese. It also accepts 两, the financial digits 壹 to 玖 with 拾
+// 佰 仟, and digit strings without units such as 二〇二四.
+func ParseChinese(s string) (int64, error) {
+	rs := []rune(strings.TrimSpace(s))
+	neg := false
+	if len(rs) > 0 && (rs[0] == '负' || rs[0] == '正') {
+		neg = rs[0] == '负'
+		rs = rs[1:]
+	}
+	u, ok := parseChinese(rs, true)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidNumber, s)
+	}
+	if neg {
+		if u > 1<<63 {
+			return 0, fmt.Errorf("%w: %q", ErrOverflow, s)
+		}
+		return -int64(u), nil
+	}
+	if u > math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %q", ErrOverflow, s)
+	}
+	return int64(u), nil
+}
+
+// parseChinese parses rs, a digit string without units such as 二〇二四 is
+// accepted only if digitRuns is set, and never next to 万 or 亿.
+func parseChinese(rs []rune, digitRuns bool) (uint64, bool) {
+	if len(rs) == 0 {
+		return 0, false
+	}
+	for _, big := range []struct {
+		value uint64
+		unit  string
+	}{{1e16, "亿亿"}, {1e8, "亿"}, {1e4, "万"}} {
+		unit := []rune(big.unit)
+		i := lastRunes(rs, unit)
+		if i < 0 {
+			continue
+		}
+		hi, ok := parseChinese(rs[:i], false)
+		// 一万亿 is fine, 一亿亿 is written with its own unit
+		if !ok || hi > math.MaxUint64/big.value || big.value < 1e16 && hi >= big.value {
+			return 0, false
+		}
+		var lo uint64
+		if j := i + len(unit); j < len(rs) {
+			if lo, ok = parseChinese(rs[j:], false); !ok || lo >= big.value {
+				return 0, false
+			}
+		}
+		return hi*big.value + lo, true
+	}
+	return parseChineseSection(rs, digitRuns)
+}
+
+// lastRunes returns the index of the last occurrence of sub in rs, or -1.
+func lastRunes(rs, sub []rune) int {
+	for i := len(rs) - len(sub); i >= 0; i-- {
+		if string(rs[i:i+len(sub)]) == string(sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseChineseSection parses a number below 10000 without 万 and 亿.
+func parseChineseSection(rs []rune, digitRuns bool) (uint64, bool) {
+	hasUnit := false
+	for _, r := range rs {
+		if _, ok := chineseUnitValues[r]; ok {
+			hasUnit = true
+			break
+		}
+	}
+	if !hasUnit && !digitRuns {
+		// a single digit, after a 零 that stands for skipped units
+		if len(rs) == 2 {
+			z, zok := chineseDigitValues[rs[0]]
+			d, ok := chineseDigitValues[rs[1]]
+			return d, zok && z == 0 && ok && d != 0
+		}
+		d, ok := chineseDigitValues[rs[0]]
+		return d, ok && len(rs) == 1
+	}
+	if !hasUnit {
+		// a digit string such as 二〇二四
+		var v uint64
+		for _, r := range rs {
+			d, ok := chineseDigitValues[r]
+			if !ok || v > (math.MaxUint64-d)/10 {
+				return 0, false
+			}
+			v = v*10 + d
+		}
+		return v, true
+	}
+
+	var sum, digit uint64
+	hasDigit := false
+	last := uint64(10000)
+	for i, r := range rs {
+		if d, ok := chineseDigitValues[r]; ok {
+			if hasDigit && digit != 0 {
+				return 0, false
+			}
+			digit, hasDigit = d, true
+			continue
+		}
+		unit := chineseUnitValues[r]
+		if unit == 0 || unit >= last {
+			return 0, false
+		}
+		switch {
+		case hasDigit && digit != 0:
+		case i == 0 && unit == 10:
+			// 十五 is 一十五
+			digit = 1
+		default:
+			return 0, false
+		}
+		sum += digit * unit
+		digit, hasDigit, last = 0, false, unit
+	}
+	return sum + digit, true
+}
@@ -0,0 +1,85 @@
+package number
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRoman(t *testing.T) {
+	cases := map[int]string{1: "I", 4: "IV", 9: "IX", 14: "XIV", 40: "XL", 90: "XC", 400: "CD", 1994: "MCMXCIV", 2024: "MMXXIV", 3999: "MMMCMXCIX"}
+	for n, want := range cases {
+		if got, err := FormatRoman(n); err != nil || got != want {
+			t.Errorf("FormatRoman(%d) = %q, %v", n, got, err)
+		}
+		if got, err := ParseRoman(want); err != nil || got != n {
+			t.Errorf("ParseRoman(%q) = %d, %v", want, got, err)
+		}
+	}
+	if got, err := ParseRoman("mcmxciv"); err != nil || got != 1994 {
+		t.Errorf("ParseRoman(lower case) = %d, %v", got, err)
+	}
+	for _, n := range []int{0, -1, 4000} {
+		if _, err := FormatRoman(n); !errors.Is(err, ErrOverflow) {
+			t.Errorf("FormatRoman(%d) error = %v", n, err)
+		}
+	}
+	for _, s := range []string{"", "IIII", "IC", "VX", "ABC", "MMMM"} {
+		if _, err := ParseRoman(s); !errors.Is(err, ErrInvalidNumber) {
+			t.Errorf("ParseRoman(%q) error = %v", s, err)
+		}
+	}
+}
+
+func TestChinese(t *testing.T) {
+	cases := []struct {
+		n    int64
+		want string
+	}{
+		{0, "零"},
+		{5, "五"},
+		{10, "十"},
+		{15, "十五"},
+		{20, "二十"},
+		{105, "一百零五"},
+		{110, "一百一十"},
+		{123, "一百二十三"},
+		{1001, "一千零一"},
+		{1010, "一千零一十"},
+		{10005, "一万零五"},
+		{100010, "十万零一十"},
+		{1200000, "一百二十万"},
+		{100000000, "一亿"},
+		{100001000, "一亿零一千"},
+		{350000000, "三亿五千万"},
+		{1500000000000, "一万五千亿"},
+		{1000500000000, "一万零五亿"},
+		{-2, "负二"},
+		{math.MaxInt64, "九百二十二亿亿三千三百七十二万零三百六十八亿五千四百七十七万五千八百零七"},
+		{math.MinInt64, "负九百二十二亿亿三千三百七十二万零三百六十八亿五千四百七十七万五千八百零八"},
+	}
+	for _, c := range cases {
+		if got := FormatChinese(c.n); got != c.want {
+			t.Errorf("FormatChinese(%d) = %q, want %q", c.n, got, c.want)
+		}
+		if got, err := ParseChinese(c.want); err != nil || got != c.n {
+			t.Errorf("ParseChinese(%q) = %d, %v", c.want, got, err)
+		}
+	}
+
+	parse := map[string]int64{"两千": 2000, "二〇二四": 2024, "壹佰贰拾叁": 123, "一万亿": 1e12, "正十": 10}
+	for s, want := range parse {
+		if got, err := ParseChinese(s); err != nil || got != want {
+			t.Errorf("ParseChinese(%q) = %d, %v, want %d", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "负", "百", "一十十", "十百", "五万三万", "abc", "一千零万零零五万",
+		"一万二三", "二三万", "一二亿三四"} {
+		if _, err := ParseChinese(s); !errors.Is(err, ErrInvalidNumber) {
+			t.Errorf("ParseChinese(%q) error = %v", s, err)
+		}
+	}
+	if _, err := ParseChinese("一千亿亿"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ParseChinese overflow error = %v", err)
+	}
+}
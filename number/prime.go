@@ -0,0 +1,198 @@
+package number
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// ErrNoInverse is returned by ModInverse when a and m are not coprime.
+var ErrNoInverse = errors.New("no modular inverse")
+
+// mulMod returns a*b mod m without overflowing.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// ModPow returns base^exp mod m. It panics if m is 0.
+func ModPow(base, exp, m uint64) uint64 {
+	if m == 1 {
+		return 0
+	}
+	result, base := uint64(1), base%m
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+	}
+	return result
+}
+
+// ModInverse returns x in [0, m) with a*x ≡ 1 (mod m), or ErrNoInverse if
+// a and m are not coprime. m must be positive.
+func ModInverse(a, m int64) (int64, error) {
+	if m <= 0 {
+		return 0, errors.New("modulus must be positive")
+	}
+	// extended Euclid on (a mod m, m)
+	r0, r1 := m, a%m
+	if r1 < 0 {
+		r1 += m
+	}
+	t0, t1 := int64(0), int64(1)
+	for r1 != 0 {
+		q := r0 / r1
+		r0, r1 = r1, r0-q*r1
+		t0, t1 = t1, t0-q*t1
+	}
+	if r0 != 1 {
+		return 0, ErrNoInverse
+	}
+	if t0 < 0 {
+		t0 += m
+	}
+	return t0 % m, nil
+}
+
+// millerRabinBases make the Miller-Rabin test deterministic below 2^64.
+var millerRabinBases = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// IsPrime reports whether n is prime. It uses trial division by the bases
+// then a deterministic Miller-Rabin test, exact for every uint64.
+func IsPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range millerRabinBases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+
+	d, s := n-1, 0
+	for d&1 == 0 {
+		d >>= 1
+		s++
+	}
+next:
+	for _, a := range millerRabinBases {
+		x := ModPow(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		for i := 1; i < s; i++ {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				continue next
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// NextPrime returns the smallest prime >= n, and false if there is none
+// below 2^64.
+func NextPrime(n uint64) (uint64, bool) {
+	if n <= 2 {
+		return 2, true
+	}
+	if n&1 == 0 {
+		n++
+	}
+	for ; n >= 3; n += 2 {
+		if IsPrime(n) {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// Primes returns the primes <= n in increasing order with the sieve of
+// Eratosthenes.
+func Primes(n int) []int {
+	if n < 2 {
+		return nil
+	}
+	composite := make([]bool, n+1)
+	primes := make([]int, 0, estimatePrimes(float64(n)))
+	for i := 2; i <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, i)
+		for j := i * i; j <= n && j > 0; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+// sieveBase bounds the base primes PrimesBetween sieves with.
+const sieveBase = 1 << 20
+
+// PrimesBetween returns the primes in [lo, hi] in increasing order with a
+// segmented sieve. Memory grows with the width of the window plus at most
+// the primes below 2^20: above 2^40, where sieving would need more base
+// primes, the numbers left by the sieve are checked with IsPrime.
+func PrimesBetween(lo, hi uint64) []uint64 {
+	if lo < 2 {
+		lo = 2
+	}
+	if hi < lo {
+		return nil
+	}
+	root, verify := sqrt64(hi), false
+	if root > sieveBase {
+		root, verify = sieveBase, true
+	}
+	small := Primes(int(root))
+
+	composite := make([]bool, hi-lo+1)
+	for _, p := range small {
+		q := uint64(p)
+		start := q * q
+		if start < lo {
+			start = lo + (q-lo%q)%q
+			if start < lo {
+				continue // no multiple of q up to MaxUint64
+			}
+		}
+		for j := start; j <= hi && j >= start; j += q {
+			composite[j-lo] = true
+		}
+	}
+
+	var primes []uint64
+	for i, c := range composite {
+		if v := lo + uint64(i); !c && (!verify || IsPrime(v)) {
+			primes = append(primes, v)
+		}
+	}
+	return primes
+}
+
+// sqrt64 returns the floor of the square root of n.
+func sqrt64(n uint64) uint64 {
+	r := uint64(math.Sqrt(float64(n)))
+	if r >= 1<<32 {
+		r = 1<<32 - 1
+	}
+	for r*r > n {
+		r--
+	}
+	for r+1 < 1<<32 && (r+1)*(r+1) <= n {
+		r++
+	}
+	return r
+}
+
+// estimatePrimes approximates the number of primes <= n, to size slices.
+func estimatePrimes(n float64) int {
+	if n < 3 {
+		return 1
+	}
+	return int(n/math.Log(n)*1.2) + 1
+}
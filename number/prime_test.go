@@ -0,0 +1,102 @@
+package number
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestIsPrime(t *testing.T) {
+	primes := Primes(10000)
+	isPrime := make(map[int]bool, len(primes))
+	for _, p := range primes {
+		isPrime[p] = true
+	}
+	for n := 0; n <= 10000; n++ {
+		if IsPrime(uint64(n)) != isPrime[n] {
+			t.Fatalf("IsPrime(%d) = %v", n, !isPrime[n])
+		}
+	}
+
+	cases := []uint64{
+		3215031751,           // strong pseudoprime to bases 2, 3, 5, 7
+		3825123056546413051,  // strong pseudoprime to bases up to 23
+		18446744073709551557, // largest prime below 2^64
+		18446744073709551615,
+		4294967291 * 4294967279,
+		1000000007,
+	}
+	for _, n := range cases {
+		want := new(big.Int).SetUint64(n).ProbablyPrime(20)
+		if got := IsPrime(n); got != want {
+			t.Errorf("IsPrime(%d) = %v, want %v", n, got, want)
+		}
+	}
+
+	if p, ok := NextPrime(1000000000); !ok || p != 1000000007 {
+		t.Errorf("NextPrime(1e9) = %d, %v", p, ok)
+	}
+	if _, ok := NextPrime(math.MaxUint64 - 1); ok {
+		t.Error("NextPrime beyond the largest uint64 prime should fail")
+	}
+}
+
+func TestPrimes(t *testing.T) {
+	if got := Primes(30); !reflect.DeepEqual(got, []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}) {
+		t.Errorf("Primes(30) = %v", got)
+	}
+	if Primes(1) != nil || len(Primes(100000)) != 9592 {
+		t.Error("Primes count")
+	}
+	if got := PrimesBetween(0, 10); !reflect.DeepEqual(got, []uint64{2, 3, 5, 7}) {
+		t.Errorf("PrimesBetween(0, 10) = %v", got)
+	}
+	got := PrimesBetween(1e12, 1e12+100)
+	for _, p := range got {
+		if !IsPrime(p) {
+			t.Errorf("PrimesBetween returned composite %d", p)
+		}
+	}
+	if len(got) != 4 {
+		t.Errorf("PrimesBetween(1e12, 1e12+100) = %v", got)
+	}
+	if PrimesBetween(10, 5) != nil {
+		t.Error("empty range")
+	}
+	// the largest primes below 2^64 are 2^64-95, 2^64-83 and 2^64-59
+	top := PrimesBetween(math.MaxUint64-100, math.MaxUint64)
+	if !reflect.DeepEqual(top, []uint64{math.MaxUint64 - 94, math.MaxUint64 - 82, math.MaxUint64 - 58}) {
+		t.Errorf("PrimesBetween(MaxUint64-100, MaxUint64) = %v", top)
+	}
+	if got := PrimesBetween(math.MaxUint64, math.MaxUint64); got != nil {
+		t.Errorf("PrimesBetween(MaxUint64, MaxUint64) = %v", got)
+	}
+}
+
+func TestModular(t *testing.T) {
+	if got := ModPow(2, 10, 1000); got != 24 {
+		t.Errorf("ModPow(2, 10, 1000) = %d", got)
+	}
+	if got := ModPow(3, 0, 7); got != 1 {
+		t.Errorf("ModPow(3, 0, 7) = %d", got)
+	}
+	m := uint64(18446744073709551557)
+	if got := ModPow(123456789, m-1, m); got != 1 {
+		t.Errorf("Fermat ModPow = %d", got)
+	}
+
+	if got, err := ModInverse(3, 11); err != nil || got != 4 {
+		t.Errorf("ModInverse(3, 11) = %d, %v", got, err)
+	}
+	if got, err := ModInverse(-3, 11); err != nil || got != 7 {
+		t.Errorf("ModInverse(-3, 11) = %d, %v", got, err)
+	}
+	if _, err := ModInverse(6, 9); !errors.Is(err, ErrNoInverse) {
+		t.Errorf("ModInverse(6, 9) error = %v", err)
+	}
+	if _, err := ModInverse(1, 0); err == nil {
+		t.Error("ModInverse with modulus 0 should fail")
+	}
+}
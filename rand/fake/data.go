@@ -0,0 +1,76 @@
+package fake
+
+var (
+	firstNames = []string{
+		"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda",
+		"William", "Elizabeth", "David", "Barbara", "Richard", "Susan", "Joseph", "Jessica",
+		"Thomas", "Sarah", "Charles", "Karen", "Daniel", "Nancy", "Matthew", "Lisa",
+		"Anthony", "Emily", "Mark", "Olivia", "Steven", "Emma",
+	}
+	lastNames = []string{
+		"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis",
+		"Rodriguez", "Martinez", "Wilson", "Anderson", "Taylor", "Thomas", "Moore", "Jackson",
+		"Martin", "Lee", "Thompson", "White", "Harris", "Clark", "Lewis", "Walker",
+		"Hall", "Allen", "Young", "King", "Wright", "Scott",
+	}
+
+	chineseSurnames = []string{
+		"王", "李", "张", "刘", "陈", "杨", "黄", "赵", "吴", "周",
+		"徐", "孙", "马", "朱", "胡", "郭", "何", "高", "林", "罗",
+		"郑", "梁", "谢", "宋", "唐", "许", "韩", "冯", "邓", "曹",
+		"欧阳", "司马", "诸葛",
+	}
+	chineseGivenChars = []string{
+		"伟", "芳", "娜", "敏", "静", "丽", "强", "磊", "军", "洋",
+		"勇", "艳", "杰", "娟", "涛", "明", "超", "秀", "霞", "平",
+		"刚", "桂", "英", "华", "玉", "萍", "红", "建", "文", "辉",
+		"宇", "欣", "浩", "然", "子", "涵", "梓", "睿", "思", "雨",
+	}
+
+	emailDomains = []string{"example.com", "example.org", "example.net", "mail.test", "corp.test"}
+
+	mobilePrefixes = []string{
+		"130", "131", "132", "133", "134", "135", "136", "137", "138", "139",
+		"150", "151", "152", "153", "155", "156", "157", "158", "159",
+		"166", "170", "176", "177", "178",
+		"180", "181", "182", "183", "184", "185", "186", "187", "188", "189",
+		"191", "198", "199",
+	}
+
+	streetNames = []string{
+		"Main", "Oak", "Pine", "Maple", "Cedar", "Elm", "Washington", "Lake",
+		"Hill", "Park", "Sunset", "Lincoln", "River", "Church", "Highland",
+	}
+	streetSuffixes = []string{"St", "Ave", "Rd", "Blvd", "Ln", "Dr", "Way", "Ct"}
+	cities         = []struct{ name, state string }{
+		{"Springfield", "IL"}, {"Austin", "TX"}, {"Portland", "OR"}, {"Denver", "CO"},
+		{"Madison", "WI"}, {"Columbus", "OH"}, {"Raleigh", "NC"}, {"Boise", "ID"},
+		{"Albany", "NY"}, {"Salem", "MA"}, {"Tucson", "AZ"}, {"Omaha", "NE"},
+	}
+
+	chineseCities = []struct {
+		city      string
+		districts []string
+	}{
+		{"北京市", []string{"朝阳区", "海淀区", "东城区", "西城区", "丰台区"}},
+		{"上海市", []string{"浦东新区", "黄浦区", "徐汇区", "静安区", "长宁区"}},
+		{"广东省广州市", []string{"天河区", "越秀区", "海珠区", "白云区"}},
+		{"广东省深圳市", []string{"南山区", "福田区", "罗湖区", "宝安区"}},
+		{"浙江省杭州市", []string{"西湖区", "上城区", "拱墅区", "滨江区"}},
+		{"江苏省南京市", []string{"玄武区", "秦淮区", "鼓楼区", "建邺区"}},
+		{"四川省成都市", []string{"锦江区", "青羊区", "武侯区", "成华区"}},
+		{"湖北省武汉市", []string{"江汉区", "武昌区", "洪山区", "汉阳区"}},
+	}
+	chineseRoads = []string{
+		"人民路", "解放路", "建设路", "中山路", "和平路", "新华路", "文化路", "长江路",
+		"科技路", "学院路", "花园路", "滨江大道",
+	}
+
+	words = []string{
+		"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel",
+		"india", "juliet", "kilo", "lima", "mike", "november", "oscar", "papa",
+		"quebec", "romeo", "sierra", "tango", "uniform", "victor", "whiskey", "xray",
+		"yankee", "zulu",
+	}
+	companySuffixes = []string{"Inc", "LLC", "Group", "Labs", "Systems", "Holdings"}
+)
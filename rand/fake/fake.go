@@ -0,0 +1,293 @@
+// Package fake generates realistic fake data for tests and fixtures:
+// names, emails, phones, addresses, IPs, dates and uuids, one value at a
+// time or by filling the fields of a struct tagged fake:"...". A Faker
+// built from a seed always produces the same data.
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hy-shine/gotiny/id"
+	"github.com/hy-shine/gotiny/netx"
+	"github.com/hy-shine/gotiny/rand"
+	"github.com/hy-shine/gotiny/timex"
+)
+
+// ErrUnknownProvider is returned for a tag naming no registered provider.
+var ErrUnknownProvider = errors.New("unknown fake provider")
+
+// Provider generates a value for a tag. args are the comma separated
+// values after a colon, fake:"int:1,100" calls the int provider with
+// "1" and "100".
+type Provider func(f *Faker, args ...string) (any, error)
+
+var (
+	providerMu sync.RWMutex
+	providers  map[string]Provider
+)
+
+// Register makes p available to every Faker under name, replacing a
+// builtin of the same name.
+func Register(name string, p Provider) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	providers[name] = p
+}
+
+// Faker generates fake data from a rand.Rand. It is safe for concurrent
+// use, but only a Faker used by one goroutine is deterministic.
+type Faker struct {
+	r *rand.Rand
+
+	mu  sync.RWMutex
+	own map[string]Provider
+}
+
+// New returns a Faker drawing from r, or from rand.Default if r is nil.
+func New(r *rand.Rand) *Faker {
+	if r == nil {
+		r = rand.Default()
+	}
+	return &Faker{r: r}
+}
+
+// NewSeeded returns a deterministic Faker: the same seed gives the same data.
+func NewSeeded(seed int64) *Faker {
+	return New(rand.NewSeeded(seed))
+}
+
+// Rand returns the generator of f.
+func (f *Faker) Rand() *rand.Rand {
+	return f.r
+}
+
+// Register makes p available to f only under name, taking precedence over
+// the providers registered with the package Register.
+func (f *Faker) Register(name string, p Provider) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.own == nil {
+		f.own = make(map[string]Provider)
+	}
+	f.own[name] = p
+}
+
+func (f *Faker) provider(name string) (Provider, bool) {
+	f.mu.RLock()
+	p, ok := f.own[name]
+	f.mu.RUnlock()
+	if ok {
+		return p, true
+	}
+	providerMu.RLock()
+	defer providerMu.RUnlock()
+	p, ok = providers[name]
+	return p, ok
+}
+
+// Generate returns a value of the provider name.
+func (f *Faker) Generate(name string, args ...string) (any, error) {
+	p, ok := f.provider(name)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownProvider, name)
+	}
+	return p(f, args...)
+}
+
+// Int returns a random int in [min, max], min if max < min.
+func (f *Faker) Int(min, max int) int {
+	v, err := rand.IntRange(f.r, min, max)
+	if err != nil {
+		return min
+	}
+	return v
+}
+
+// Float returns a random float64 in [min, max), min if max <= min.
+func (f *Faker) Float(min, max float64) float64 {
+	v, err := rand.FloatRange(f.r, min, max)
+	if err != nil {
+		return min
+	}
+	return v
+}
+
+// Bool returns a random bool.
+func (f *Faker) Bool() bool {
+	return f.r.Bool()
+}
+
+// Digits returns n random decimal digits.
+func (f *Faker) Digits(n int) string {
+	return f.r.Code(n)
+}
+
+// String returns n random alphanumeric characters.
+func (f *Faker) String(n int) string {
+	return f.r.String(n, rand.Alphanumeric)
+}
+
+// OneOf returns one of values, "" if there is none.
+func (f *Faker) OneOf(values ...string) string {
+	v, _ := rand.Choice(f.r, values)
+	return v
+}
+
+// FirstName returns an English first name.
+func (f *Faker) FirstName() string {
+	return f.OneOf(firstNames...)
+}
+
+// LastName returns an English last name.
+func (f *Faker) LastName() string {
+	return f.OneOf(lastNames...)
+}
+
+// Name returns an English full name such as "Emily Clark".
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// ChineseName returns a Chinese name of two or three characters, or more
+// with a compound surname, such as "王芳" or "欧阳子涵".
+func (f *Faker) ChineseName() string {
+	name := f.OneOf(chineseSurnames...) + f.OneOf(chineseGivenChars...)
+	if f.r.Intn(3) > 0 {
+		name += f.OneOf(chineseGivenChars...)
+	}
+	return name
+}
+
+// Username returns a login name such as "emily42".
+func (f *Faker) Username() string {
+	return strings.ToLower(f.FirstName()) + f.Digits(2)
+}
+
+// Email returns an address on a reserved example domain, such as
+// "emily.clark7@example.com".
+func (f *Faker) Email() string {
+	local := strings.ToLower(f.FirstName() + "." + f.LastName())
+	if f.Bool() {
+		local += strconv.Itoa(f.Int(1, 99))
+	}
+	return local + "@" + f.OneOf(emailDomains...)
+}
+
+// Phone returns a Chinese mobile number of 11 digits.
+func (f *Faker) Phone() string {
+	return f.OneOf(mobilePrefixes...) + f.Digits(8)
+}
+
+// Address returns a US style address such as
+// "742 Maple Ave, Springfield, IL 62704".
+func (f *Faker) Address() string {
+	c := cities[f.r.Intn(len(cities))]
+	return fmt.Sprintf("%d %s %s, %s, %s %05d", f.Int(1, 9999), f.OneOf(streetNames...),
+		f.OneOf(streetSuffixes...), c.name, c.state, f.Int(10000, 99999))
+}
+
+// ChineseAddress returns a Chinese address such as "北京市朝阳区建设路88号".
+func (f *Faker) ChineseAddress() string {
+	c := chineseCities[f.r.Intn(len(chineseCities))]
+	return fmt.Sprintf("%s%s%s%d号", c.city, f.OneOf(c.districts...), f.OneOf(chineseRoads...), f.Int(1, 999))
+}
+
+// IPv4 returns a public unicast IPv4 address in dotted form.
+func (f *Faker) IPv4() string {
+	for {
+		ip := netx.LongToIPv4Str(uint32(f.r.Uint64()))
+		first, _ := strconv.Atoi(ip[:strings.IndexByte(ip, '.')])
+		if _, private := netx.IsPrivateIP(ip); private || first == 0 || first == 127 || first >= 224 {
+			continue
+		}
+		return ip
+	}
+}
+
+// PrivateIPv4 returns an address of the private ranges 10.0.0.0/8,
+// 172.16.0.0/12 or 192.168.0.0/16.
+func (f *Faker) PrivateIPv4() string {
+	base := [...]struct {
+		start uint32
+		bits  uint
+	}{{0x0a000000, 24}, {0xac100000, 20}, {0xc0a80000, 16}}[f.r.Intn(3)]
+	return netx.LongToIPv4Str(base.start | uint32(f.r.Uint64n(1<<base.bits)))
+}
+
+// IPv6 returns a global unicast IPv6 address in the 2000::/3 range.
+func (f *Faker) IPv6() string {
+	ip := make(net.IP, net.IPv6len)
+	_, _ = f.r.Read(ip)
+	ip[0] = 0x20 | ip[0]&0x1f
+	return ip.String()
+}
+
+// UTC keeps seeded output the same on every host.
+var (
+	minTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxTime = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Time returns a random time in [from, to) with a second precision, from
+// if to is not after it.
+func (f *Faker) Time(from, to time.Time) time.Time {
+	span := to.Unix() - from.Unix()
+	if span <= 0 {
+		return from
+	}
+	return from.Add(time.Duration(f.r.Uint64n(uint64(span))) * time.Second)
+}
+
+// Date returns a date between 2000 and 2030 in the timex.DateLayout.
+func (f *Faker) Date() string {
+	return timex.DateString(f.Time(minTime, maxTime))
+}
+
+// DateTime returns a time between 2000 and 2030 in the timex.DateTimeLayout.
+func (f *Faker) DateTime() string {
+	return timex.DateTimeString(f.Time(minTime, maxTime))
+}
+
+// UUID returns a version 4 uuid drawn from the generator of f.
+func (f *Faker) UUID() string {
+	u, err := id.NewUuidFromReader(f.r)
+	if err != nil {
+		// a rand.Rand never fails to read
+		panic(err)
+	}
+	return u
+}
+
+// Word returns a lower-case word.
+func (f *Faker) Word() string {
+	return f.OneOf(words...)
+}
+
+// Sentence returns n words, capitalized and ended by a period.
+func (f *Faker) Sentence(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	ws := make([]string, n)
+	for i := range ws {
+		ws[i] = f.Word()
+	}
+	s := strings.Join(ws, " ")
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
+
+// Company returns a company name such as "Clark Labs".
+func (f *Faker) Company() string {
+	return f.LastName() + " " + f.OneOf(companySuffixes...)
+}
+
+// URL returns an https URL on a reserved example domain.
+func (f *Faker) URL() string {
+	return "https://www." + f.OneOf(emailDomains...) + "/" + f.Word()
+}
@@ -0,0 +1,225 @@
+package fake
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hy-shine/gotiny/netx"
+	"github.com/hy-shine/gotiny/timex"
+)
+
+func TestValues(t *testing.T) {
+	f := NewSeeded(1)
+	email := regexp.MustCompile(`^[a-z]+\.[a-z]+[0-9]*@[a-z.]+$`)
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for i := 0; i < 200; i++ {
+		if e := f.Email(); !email.MatchString(e) {
+			t.Fatalf("Email() = %q", e)
+		}
+		if p := f.Phone(); len(p) != 11 || p[0] != '1' || strings.Trim(p, "0123456789") != "" {
+			t.Fatalf("Phone() = %q", p)
+		}
+		if n := utf8.RuneCountInString(f.ChineseName()); n < 2 || n > 4 {
+			t.Fatalf("ChineseName() has %d runes", n)
+		}
+		if ip := f.IPv4(); !netx.IsIPv4(ip) {
+			t.Fatalf("IPv4() = %q", ip)
+		} else if _, private := netx.IsPrivateIP(ip); private {
+			t.Fatalf("IPv4() = %q is private", ip)
+		}
+		if ip := f.PrivateIPv4(); !netx.IsIPv4(ip) {
+			t.Fatalf("PrivateIPv4() = %q", ip)
+		} else if _, private := netx.IsPrivateIP(ip); !private {
+			t.Fatalf("PrivateIPv4() = %q is public", ip)
+		}
+		if ip := f.IPv6(); !netx.IsIPv6(ip) || !net.ParseIP(ip).IsGlobalUnicast() {
+			t.Fatalf("IPv6() = %q", ip)
+		}
+		if u := f.UUID(); !uuid.MatchString(u) {
+			t.Fatalf("UUID() = %q", u)
+		}
+		if d, err := time.Parse(timex.DateLayout, f.Date()); err != nil || d.Year() < 2000 || d.Year() >= 2030 {
+			t.Fatalf("Date() = %v, %v", d, err)
+		}
+		if _, err := time.Parse(timex.DateTimeLayout, f.DateTime()); err != nil {
+			t.Fatalf("DateTime() error = %v", err)
+		}
+	}
+	if s := f.Sentence(3); len(strings.Fields(s)) != 3 || !strings.HasSuffix(s, ".") {
+		t.Errorf("Sentence(3) = %q", s)
+	}
+	if !strings.Contains(f.ChineseAddress(), "号") || !strings.Contains(f.Address(), ", ") {
+		t.Error("Address")
+	}
+}
+
+type address struct {
+	City   string `fake:"chinese_address"`
+	Street string
+}
+
+type user struct {
+	ID        int64      `fake:"int:1,1000"`
+	Name      string     `fake:"chinese_name"`
+	EnName    string     `fake:"name"`
+	Email     string     `fake:"email"`
+	Phone     string     `fake:"phone"`
+	Code      int        `fake:"digits:4"`
+	Level     string     `fake:"oneof:gold,silver,bronze"`
+	Score     float32    `fake:"float:0,5"`
+	Active    bool       `fake:"bool"`
+	IP        string     `fake:"ipv4"`
+	UUID      string     `fake:"uuid"`
+	Birthday  time.Time  `fake:"date"`
+	CreatedAt time.Time  `fake:"time"`
+	UpdatedAt string     `fake:"time"`
+	DeletedAt *time.Time `fake:"datetime"`
+	Nickname  *string    `fake:"username"`
+	Home      address
+	Work      *address
+	Ignored   string `fake:"-"`
+	Untagged  string
+	secret    string `fake:"email"`
+}
+
+func TestStruct(t *testing.T) {
+	var a, b user
+	a.Work, b.Work = &address{}, &address{}
+	if err := NewSeeded(42).Struct(&a); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewSeeded(42).Struct(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("same seed, different structs:\n%+v\n%+v", a, b)
+	}
+
+	if a.ID < 1 || a.ID > 1000 || a.Code > 9999 || a.Score < 0 || a.Score >= 5 {
+		t.Errorf("numbers out of range: %+v", a)
+	}
+	if a.Level != "gold" && a.Level != "silver" && a.Level != "bronze" {
+		t.Errorf("Level = %q", a.Level)
+	}
+	if a.Name == "" || a.Email == "" || a.Phone == "" || a.UUID == "" || a.IP == "" {
+		t.Errorf("empty fields: %+v", a)
+	}
+	if a.Birthday.IsZero() || a.Birthday.Hour() != 0 || a.CreatedAt.IsZero() || a.DeletedAt == nil {
+		t.Errorf("times: %v, %v, %v", a.Birthday, a.CreatedAt, a.DeletedAt)
+	}
+	if a.CreatedAt.Location() != time.UTC || a.Birthday.Location() != time.UTC {
+		t.Errorf("times not in UTC: %v, %v", a.CreatedAt, a.Birthday)
+	}
+	if _, err := time.Parse(timex.DateTimeLayout, a.UpdatedAt); err != nil {
+		t.Errorf("UpdatedAt = %q", a.UpdatedAt)
+	}
+	if a.Nickname == nil || *a.Nickname == "" || a.Home.City == "" || a.Work.City == "" {
+		t.Errorf("nested fields: %+v", a)
+	}
+	if a.Ignored != "" || a.Untagged != "" || a.secret != "" || a.Home.Street != "" {
+		t.Errorf("untagged fields were filled: %+v", a)
+	}
+
+	var c user
+	if err := NewSeeded(43).Struct(&c); err != nil || c.Email == a.Email && c.UUID == a.UUID {
+		t.Errorf("another seed gives the same data: %v", err)
+	}
+	if c.Work != nil {
+		t.Error("a nil pointer without tag should stay nil")
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	f := NewSeeded(1)
+	if err := f.Struct(user{}); err == nil {
+		t.Error("Struct should reject a non-pointer")
+	}
+	var unknown struct {
+		X string `fake:"nope"`
+	}
+	if err := f.Struct(&unknown); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("unknown provider error = %v", err)
+	}
+	var small struct {
+		X int8 `fake:"int:1000,2000"`
+	}
+	if err := f.Struct(&small); err == nil {
+		t.Error("an int8 should not hold 1000")
+	}
+	var bad struct {
+		X []int `fake:"email"`
+	}
+	if err := f.Struct(&bad); err == nil {
+		t.Error("a string should not go into []int")
+	}
+	var args struct {
+		X int `fake:"int:a,b"`
+	}
+	if err := f.Struct(&args); err == nil {
+		t.Error("bad int arguments should fail")
+	}
+	var unsigned struct {
+		X uint `fake:"int:-5,-1"`
+	}
+	if err := f.Struct(&unsigned); err == nil {
+		t.Errorf("a uint should not hold a negative number, got %d", unsigned.X)
+	}
+	type node struct {
+		Name string `fake:"name"`
+		Next *node
+	}
+	cycle := &node{}
+	cycle.Next = cycle
+	if err := f.Struct(cycle); err == nil {
+		t.Error("a pointer cycle should fail")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("sku", func(f *Faker, args ...string) (any, error) {
+		return "SKU-" + f.Digits(6), nil
+	})
+	f := NewSeeded(7)
+	f.Register("email", func(*Faker, ...string) (any, error) {
+		return "fixed@example.com", nil
+	})
+	var item struct {
+		SKU   string `fake:"sku"`
+		Owner string `fake:"email"`
+	}
+	if err := f.Struct(&item); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(item.SKU, "SKU-") || len(item.SKU) != 10 || item.Owner != "fixed@example.com" {
+		t.Errorf("custom providers = %+v", item)
+	}
+	if v, _ := NewSeeded(7).Generate("email"); v == "fixed@example.com" {
+		t.Error("an instance provider leaked to another Faker")
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	f := New(nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				var u user
+				if err := f.Struct(&u); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,292 @@
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hy-shine/gotiny/timex"
+)
+
+func init() {
+	str := func(g func(f *Faker) string) Provider {
+		return func(f *Faker, _ ...string) (any, error) { return g(f), nil }
+	}
+	providers = map[string]Provider{
+		"name":            str((*Faker).Name),
+		"first_name":      str((*Faker).FirstName),
+		"last_name":       str((*Faker).LastName),
+		"chinese_name":    str((*Faker).ChineseName),
+		"username":        str((*Faker).Username),
+		"email":           str((*Faker).Email),
+		"phone":           str((*Faker).Phone),
+		"address":         str((*Faker).Address),
+		"chinese_address": str((*Faker).ChineseAddress),
+		"ipv4":            str((*Faker).IPv4),
+		"private_ipv4":    str((*Faker).PrivateIPv4),
+		"ipv6":            str((*Faker).IPv6),
+		"date":            str((*Faker).Date),
+		"datetime":        str((*Faker).DateTime),
+		"uuid":            str((*Faker).UUID),
+		"word":            str((*Faker).Word),
+		"company":         str((*Faker).Company),
+		"url":             str((*Faker).URL),
+		"time": func(f *Faker, _ ...string) (any, error) {
+			return f.Time(minTime, maxTime), nil
+		},
+		"unix": func(f *Faker, _ ...string) (any, error) {
+			return f.Time(minTime, maxTime).Unix(), nil
+		},
+		"bool": func(f *Faker, _ ...string) (any, error) {
+			return f.Bool(), nil
+		},
+		"int": func(f *Faker, args ...string) (any, error) {
+			min, max, err := intArgs(args, 0, 100)
+			return f.Int(min, max), err
+		},
+		"float": func(f *Faker, args ...string) (any, error) {
+			if len(args) == 0 {
+				return f.Float(0, 1), nil
+			}
+			if len(args) != 2 {
+				return nil, fmt.Errorf("want 0 or 2 arguments, got %d", len(args))
+			}
+			min, err := strconv.ParseFloat(args[0], 64)
+			if err != nil {
+				return nil, err
+			}
+			max, err := strconv.ParseFloat(args[1], 64)
+			if err != nil {
+				return nil, err
+			}
+			return f.Float(min, max), nil
+		},
+		"digits": func(f *Faker, args ...string) (any, error) {
+			n, _, err := intArgs(args, 6, 0)
+			return f.Digits(n), err
+		},
+		"string": func(f *Faker, args ...string) (any, error) {
+			n, _, err := intArgs(args, 10, 0)
+			return f.String(n), err
+		},
+		"sentence": func(f *Faker, args ...string) (any, error) {
+			n, _, err := intArgs(args, 6, 0)
+			return f.Sentence(n), err
+		},
+		"oneof": func(f *Faker, args ...string) (any, error) {
+			if len(args) == 0 {
+				return nil, errors.New("oneof needs values")
+			}
+			return f.OneOf(args...), nil
+		},
+	}
+}
+
+// intArgs parses the optional integer arguments of a provider, def1 and
+// def2 when absent.
+func intArgs(args []string, def1, def2 int) (int, int, error) {
+	vals := []int{def1, def2}
+	if len(args) > len(vals) {
+		return 0, 0, fmt.Errorf("want at most %d arguments, got %d", len(vals), len(args))
+	}
+	for i, a := range args {
+		v, err := strconv.Atoi(strings.TrimSpace(a))
+		if err != nil {
+			return 0, 0, err
+		}
+		vals[i] = v
+	}
+	return vals[0], vals[1], nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Struct fills the fields of the struct ptr points to from their fake
+// tags, such as
+//
+//	type User struct {
+//		Name      string    `fake:"chinese_name"`
+//		Email     string    `fake:"email"`
+//		Age       int       `fake:"int:18,60"`
+//		Level     string    `fake:"oneof:gold,silver"`
+//		CreatedAt time.Time `fake:"time"`
+//		Addr      *Address
+//	}
+//
+// Fields without tag are left alone, except structs and non-nil pointers
+// to structs which are filled recursively, and fake:"-" skips a field.
+// Values are converted to the field type: numbers between numeric types,
+// digit strings to numbers, times to strings in the timex.DateTimeLayout
+// and date strings to time.Time in UTC. Structs nested deeper than
+// maxDepth, such as a pointer cycle, are an error.
+func (f *Faker) Struct(ptr any) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("fake: Struct wants a non-nil pointer to a struct, got %T", ptr)
+	}
+	return f.fill(rv.Elem(), 0)
+}
+
+// maxDepth bounds the struct nesting Struct follows.
+const maxDepth = 32
+
+func (f *Faker) fill(rv reflect.Value, depth int) error {
+	if depth > maxDepth {
+		return fmt.Errorf("fake: %s nested deeper than %d structs", rv.Type(), maxDepth)
+	}
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		tag := sf.Tag.Get("fake")
+		switch {
+		case tag == "-":
+			continue
+		case tag == "":
+			if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct && fv.Type() != timeType {
+				if err := f.fill(fv, depth+1); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		name, args := tag, []string(nil)
+		if i := strings.IndexByte(tag, ':'); i >= 0 {
+			name, args = tag[:i], strings.Split(tag[i+1:], ",")
+		}
+		v, err := f.Generate(name, args...)
+		if err != nil {
+			return fmt.Errorf("fake: field %s: %w", sf.Name, err)
+		}
+		if err := assign(fv, v); err != nil {
+			return fmt.Errorf("fake: field %s: %w", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// assign stores v in dst, converting it to the type of dst.
+func assign(dst reflect.Value, v any) error {
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+	src := reflect.ValueOf(v)
+	if !src.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	if t, ok := v.(time.Time); ok && dst.Kind() == reflect.String {
+		dst.SetString(timex.DateTimeString(t))
+		return nil
+	}
+	if s, ok := v.(string); ok {
+		return assignString(dst, s)
+	}
+
+	switch {
+	case isNumber(src.Kind()) && isNumber(dst.Kind()):
+		if isUnsigned(dst.Kind()) && isNegative(src) {
+			return fmt.Errorf("%v does not fit %s", v, dst.Type())
+		}
+		c := src.Convert(dst.Type())
+		// floats may lose precision, integers must keep their value
+		if dst.Kind() >= reflect.Float32 && src.Kind() >= reflect.Float32 {
+			if dst.OverflowFloat(src.Float()) {
+				return fmt.Errorf("%v does not fit %s", v, dst.Type())
+			}
+		} else if c.Convert(src.Type()).Interface() != v {
+			return fmt.Errorf("%v does not fit %s", v, dst.Type())
+		}
+		dst.Set(c)
+		return nil
+	case isNumber(src.Kind()) && dst.Kind() == reflect.String:
+		dst.SetString(fmt.Sprint(v))
+		return nil
+	case src.Type().ConvertibleTo(dst.Type()) && src.Kind() == dst.Kind():
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %T to %s", v, dst.Type())
+}
+
+func assignString(dst reflect.Value, s string) error {
+	if dst.Type() == timeType {
+		t, err := time.ParseInLocation(timex.DateTimeLayout, s, time.UTC)
+		if err != nil {
+			t, err = time.ParseInLocation(timex.DateLayout, s, time.UTC)
+		}
+		if err != nil {
+			return fmt.Errorf("cannot parse %q as a time", s)
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	var err error
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(s, 10, dst.Type().Bits()); err == nil {
+			dst.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = strconv.ParseUint(s, 10, dst.Type().Bits()); err == nil {
+			dst.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		if n, err = strconv.ParseFloat(s, dst.Type().Bits()); err == nil {
+			dst.SetFloat(n)
+		}
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			dst.SetBool(b)
+		}
+	default:
+		return fmt.Errorf("cannot assign string to %s", dst.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("cannot assign %q to %s", s, dst.Type())
+	}
+	return nil
+}
+
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+func isUnsigned(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isNegative(v reflect.Value) bool {
+	switch {
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		return v.Int() < 0
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
+}
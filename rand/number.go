@@ -3,6 +3,7 @@ package rand
 import "math/rand"
 
 // RandIntRange generates a random integer between min and max (inclusive).
+// It returns 0 if min > max, use IntRange to get an error instead.
 func RandIntRange(min int, max int) int {
 	if min > max {
 		return 0
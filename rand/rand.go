@@ -0,0 +1,219 @@
+package rand
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrInvalidRange is returned when min > max or a bound is not finite.
+var ErrInvalidRange = errors.New("invalid random range")
+
+// Rand is a random generator over a pluggable math/rand.Source. Unlike
+// rand.Rand it is safe for concurrent use, the source is only called with
+// a lock held.
+type Rand struct {
+	mu  sync.Mutex
+	src rand.Source
+	s64 rand.Source64
+}
+
+// New returns a generator reading src.
+func New(src rand.Source) *Rand {
+	r := &Rand{src: src}
+	r.s64, _ = src.(rand.Source64)
+	return r
+}
+
+// NewSeeded returns a deterministic generator: the same seed gives the
+// same sequence, for reproducible tests and fixtures. Never use it for
+// secrets.
+func NewSeeded(seed int64) *Rand {
+	return New(rand.NewSource(seed))
+}
+
+// NewCrypto returns a generator backed by crypto/rand, for tokens,
+// passwords and anything an attacker must not guess.
+func NewCrypto() *Rand {
+	return New(&CryptoSource{})
+}
+
+var defaultRand = NewCrypto()
+
+// Default returns the shared crypto-secure generator the package
+// functions use when given a nil *Rand.
+func Default() *Rand {
+	return defaultRand
+}
+
+// CryptoSource is a rand.Source64 reading crypto/rand through a small
+// buffer. It is not safe for concurrent use on its own, wrap it with New.
+type CryptoSource struct {
+	buf [256]byte
+	n   int
+}
+
+// Uint64 returns 64 random bits. It panics if the system generator
+// fails, which it does not on supported platforms.
+func (s *CryptoSource) Uint64() uint64 {
+	if s.n < 8 {
+		if _, err := crand.Read(s.buf[:]); err != nil {
+			panic(fmt.Sprintf("rand: crypto/rand failed: %v", err))
+		}
+		s.n = len(s.buf)
+	}
+	s.n -= 8
+	return binary.LittleEndian.Uint64(s.buf[s.n:])
+}
+
+// Int63 returns a non-negative random int64.
+func (s *CryptoSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed does nothing, a crypto source cannot be seeded.
+func (s *CryptoSource) Seed(int64) {}
+
+func orDefault(r *Rand) *Rand {
+	if r == nil {
+		return defaultRand
+	}
+	return r
+}
+
+// Uint64 returns 64 random bits.
+func (r *Rand) Uint64() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.s64 != nil {
+		return r.s64.Uint64()
+	}
+	return uint64(r.src.Int63())>>31 | uint64(r.src.Int63())<<32
+}
+
+// Int63 returns a non-negative random int64.
+func (r *Rand) Int63() int64 {
+	return int64(r.Uint64() >> 1)
+}
+
+// Uint64n returns a uniform random number in [0, n). It panics if n is 0.
+func (r *Rand) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("rand: Uint64n with n == 0")
+	}
+	if n&(n-1) == 0 {
+		return r.Uint64() & (n - 1)
+	}
+	// Lemire's multiply-shift, rejecting the biased low products
+	threshold := -n % n
+	for {
+		hi, lo := bits.Mul64(r.Uint64(), n)
+		if lo >= threshold {
+			return hi
+		}
+	}
+}
+
+// Intn returns a uniform random int in [0, n). It panics if n <= 0.
+func (r *Rand) Intn(n int) int {
+	if n <= 0 {
+		panic("rand: Intn with n <= 0")
+	}
+	return int(r.Uint64n(uint64(n)))
+}
+
+// Float64 returns a uniform random float64 in [0, 1).
+func (r *Rand) Float64() float64 {
+	return float64(r.Uint64()>>11) / (1 << 53)
+}
+
+// Bool returns a random bool.
+func (r *Rand) Bool() bool {
+	return r.Uint64()&1 == 1
+}
+
+// Int64Range returns a uniform random int64 in [min, max], the whole
+// int64 range included. It returns ErrInvalidRange if min > max.
+func (r *Rand) Int64Range(min, max int64) (int64, error) {
+	if min > max {
+		return 0, fmt.Errorf("%w: [%d, %d]", ErrInvalidRange, min, max)
+	}
+	return min + int64(r.span(uint64(max-min))), nil
+}
+
+// Uint64Range returns a uniform random uint64 in [min, max]. It returns
+// ErrInvalidRange if min > max.
+func (r *Rand) Uint64Range(min, max uint64) (uint64, error) {
+	if min > max {
+		return 0, fmt.Errorf("%w: [%d, %d]", ErrInvalidRange, min, max)
+	}
+	return min + r.span(max-min), nil
+}
+
+// span returns a uniform random number in [0, n].
+func (r *Rand) span(n uint64) uint64 {
+	if n == math.MaxUint64 {
+		return r.Uint64()
+	}
+	return r.Uint64n(n + 1)
+}
+
+// Float64Range returns a uniform random float64 in [min, max), or min if
+// they are equal. It returns ErrInvalidRange if min > max or a bound is
+// NaN or infinite.
+func (r *Rand) Float64Range(min, max float64) (float64, error) {
+	if !(min <= max) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return 0, fmt.Errorf("%w: [%v, %v)", ErrInvalidRange, min, max)
+	}
+	if min == max {
+		return min, nil
+	}
+	for {
+		f := r.Float64()
+		v := min + f*(max-min)
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			// max-min overflowed, interpolate instead
+			v = min*(1-f) + max*f
+		}
+		if v < max {
+			return v, nil
+		}
+	}
+}
+
+// IntRange returns a uniform random integer in [min, max] from r, or from
+// Default if r is nil. It returns ErrInvalidRange if min > max.
+func IntRange[T constraints.Integer](r *Rand, min, max T) (T, error) {
+	r = orDefault(r)
+	if min > max {
+		return 0, fmt.Errorf("%w: [%d, %d]", ErrInvalidRange, min, max)
+	}
+	// the distance fits an uint64 for signed and unsigned types alike
+	if min < 0 {
+		return T(int64(min) + int64(r.span(uint64(int64(max)-int64(min))))), nil
+	}
+	return T(uint64(min) + r.span(uint64(max)-uint64(min))), nil
+}
+
+// FloatRange returns a uniform random float in [min, max) from r, or from
+// Default if r is nil. It returns ErrInvalidRange if min > max or a bound
+// is NaN or infinite.
+func FloatRange[T constraints.Float](r *Rand, min, max T) (T, error) {
+	for {
+		v, err := orDefault(r).Float64Range(float64(min), float64(max))
+		if err != nil || min == max {
+			return T(v), err
+		}
+		// float32 may round up to max
+		if T(v) < max {
+			return T(v), nil
+		}
+	}
+}
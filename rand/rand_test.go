@@ -0,0 +1,108 @@
+package rand
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestSeeded(t *testing.T) {
+	a, b := NewSeeded(42), NewSeeded(42)
+	for i := 0; i < 100; i++ {
+		x, _ := IntRange(a, -1000, 1000)
+		y, _ := IntRange(b, -1000, 1000)
+		if x != y {
+			t.Fatalf("seeded generators diverge at %d: %d != %d", i, x, y)
+		}
+	}
+	if NewSeeded(1).Uint64() == NewSeeded(2).Uint64() {
+		t.Error("different seeds give the same value")
+	}
+}
+
+func TestIntRange(t *testing.T) {
+	r := NewSeeded(7)
+	seen := make(map[int8]bool)
+	for i := 0; i < 2000; i++ {
+		v, err := IntRange(r, int8(-3), int8(3))
+		if err != nil || v < -3 || v > 3 {
+			t.Fatalf("IntRange(-3, 3) = %d, %v", v, err)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 7 {
+		t.Errorf("IntRange(-3, 3) only returned %v", seen)
+	}
+
+	if v, err := IntRange(nil, uint8(5), uint8(5)); err != nil || v != 5 {
+		t.Errorf("IntRange(5, 5) = %d, %v", v, err)
+	}
+	if _, err := IntRange(r, int64(math.MinInt64), int64(math.MaxInt64)); err != nil {
+		t.Errorf("IntRange over int64 error = %v", err)
+	}
+	if v, err := IntRange(r, uint64(math.MaxUint64-1), uint64(math.MaxUint64)); err != nil || v < math.MaxUint64-1 {
+		t.Errorf("IntRange near MaxUint64 = %d, %v", v, err)
+	}
+	if _, err := IntRange(r, 10, 5); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("IntRange(10, 5) error = %v", err)
+	}
+	if _, err := r.Int64Range(1, 0); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Int64Range(1, 0) error = %v", err)
+	}
+}
+
+func TestFloatRange(t *testing.T) {
+	r := NewSeeded(7)
+	for i := 0; i < 1000; i++ {
+		v, err := FloatRange(r, -1.5, 2.5)
+		if err != nil || v < -1.5 || v >= 2.5 {
+			t.Fatalf("FloatRange(-1.5, 2.5) = %v, %v", v, err)
+		}
+	}
+	if v, err := FloatRange(r, -math.MaxFloat64, math.MaxFloat64); err != nil || math.IsInf(v, 0) {
+		t.Errorf("FloatRange over float64 = %v, %v", v, err)
+	}
+	if v, err := FloatRange(r, float32(1), float32(1)); err != nil || v != 1 {
+		t.Errorf("FloatRange(1, 1) = %v, %v", v, err)
+	}
+	for _, c := range [][2]float64{{2, 1}, {math.NaN(), 1}, {0, math.Inf(1)}} {
+		if _, err := FloatRange(r, c[0], c[1]); !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("FloatRange(%v, %v) error = %v", c[0], c[1], err)
+		}
+	}
+}
+
+func TestUint64n(t *testing.T) {
+	r := New(&CryptoSource{})
+	counts := make([]int, 3)
+	for i := 0; i < 30000; i++ {
+		counts[r.Uint64n(3)]++
+	}
+	for i, c := range counts {
+		if c < 9000 || c > 11000 {
+			t.Errorf("Uint64n(3) returned %d %d times out of 30000", i, c)
+		}
+	}
+	if v := r.Intn(1); v != 0 {
+		t.Errorf("Intn(1) = %d", v)
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	r := NewSeeded(1)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if v := r.Float64(); v < 0 || v >= 1 {
+					t.Errorf("Float64() = %v", v)
+				}
+				_, _ = IntRange(nil, 0, 100)
+			}
+		}()
+	}
+	wg.Wait()
+}
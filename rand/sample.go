@@ -0,0 +1,173 @@
+package rand
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidWeights is returned by NewWeighted for weights it cannot use.
+var ErrInvalidWeights = errors.New("invalid weights")
+
+// Shuffle shuffles s in place with r, or with Default if r is nil.
+func Shuffle[T any](r *Rand, s []T) {
+	r = orDefault(r)
+	for i := len(s) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Choice returns a uniformly chosen element of s, and false if s is empty.
+// A nil r uses Default.
+func Choice[T any](r *Rand, s []T) (T, bool) {
+	if len(s) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s[orDefault(r).Intn(len(s))], true
+}
+
+// Sample returns k distinct elements of s, by position, in random order,
+// or all of them shuffled if k >= len(s). s is not modified and the cost
+// is O(k) whatever its length. A nil r uses Default.
+func Sample[T any](r *Rand, s []T, k int) []T {
+	r = orDefault(r)
+	if k > len(s) {
+		k = len(s)
+	}
+	if k <= 0 {
+		return nil
+	}
+	// a partial Fisher-Yates over the indexes, swapped ones kept in a map
+	swapped := make(map[int]int, k)
+	index := func(i int) int {
+		if j, ok := swapped[i]; ok {
+			return j
+		}
+		return i
+	}
+	out := make([]T, k)
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(len(s)-i)
+		out[i] = s[index(j)]
+		swapped[j] = index(i)
+	}
+	return out
+}
+
+// Reservoir keeps a uniform sample of at most k items from a stream of
+// unknown length, with Vitter's algorithm R. It is not safe for
+// concurrent use.
+type Reservoir[T any] struct {
+	r     *Rand
+	k     int
+	seen  int64
+	items []T
+}
+
+// NewReservoir returns a reservoir of k items drawing from r, or from
+// Default if r is nil. It returns ErrInvalidRange if k is not positive.
+func NewReservoir[T any](r *Rand, k int) (*Reservoir[T], error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("%w: reservoir size %d", ErrInvalidRange, k)
+	}
+	return &Reservoir[T]{r: orDefault(r), k: k, items: make([]T, 0, k)}, nil
+}
+
+// Add offers v to the sample.
+func (s *Reservoir[T]) Add(v T) {
+	s.seen++
+	if len(s.items) < s.k {
+		s.items = append(s.items, v)
+		return
+	}
+	if j := s.r.Uint64n(uint64(s.seen)); j < uint64(s.k) {
+		s.items[j] = v
+	}
+}
+
+// Seen returns how many items were offered.
+func (s *Reservoir[T]) Seen() int64 {
+	return s.seen
+}
+
+// Items returns a copy of the current sample.
+func (s *Reservoir[T]) Items() []T {
+	return append([]T(nil), s.items...)
+}
+
+// Weighted picks items with a probability proportional to their weight in
+// O(1), using Vose's alias method. It is immutable and safe for
+// concurrent use.
+type Weighted[T any] struct {
+	items []T
+	prob  []float64
+	alias []int
+}
+
+// NewWeighted builds the alias table of items. It returns
+// ErrInvalidWeights if the lengths differ, a weight is negative, NaN or
+// infinite, or all weights are zero.
+func NewWeighted[T any](items []T, weights []float64) (*Weighted[T], error) {
+	n := len(items)
+	if n == 0 || len(weights) != n {
+		return nil, ErrInvalidWeights
+	}
+	var total float64
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 0) {
+			return nil, ErrInvalidWeights
+		}
+		total += w
+	}
+	if total == 0 || math.IsInf(total, 0) {
+		return nil, ErrInvalidWeights
+	}
+
+	w := &Weighted[T]{
+		items: append([]T(nil), items...),
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, wt := range weights {
+		scaled[i] = wt * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		w.prob[s], w.alias[s] = scaled[s], l
+		scaled[l] += scaled[s] - 1
+		if scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// what is left is 1 up to rounding errors
+	for _, i := range append(small, large...) {
+		w.prob[i], w.alias[i] = 1, i
+	}
+	return w, nil
+}
+
+// Pick returns an item drawn with r, or with Default if r is nil.
+func (w *Weighted[T]) Pick(r *Rand) T {
+	r = orDefault(r)
+	i := r.Intn(len(w.items))
+	if r.Float64() < w.prob[i] {
+		return w.items[i]
+	}
+	return w.items[w.alias[i]]
+}
+
+// Len returns the number of items.
+func (w *Weighted[T]) Len() int {
+	return len(w.items)
+}
@@ -0,0 +1,137 @@
+package rand
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestShuffleChoice(t *testing.T) {
+	r := NewSeeded(5)
+	s := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	Shuffle(r, s)
+	sorted := append([]int(nil), s...)
+	sort.Ints(sorted)
+	for i, v := range sorted {
+		if v != i+1 {
+			t.Fatalf("Shuffle lost elements: %v", s)
+		}
+	}
+
+	if _, ok := Choice[int](r, nil); ok {
+		t.Error("Choice of an empty slice should fail")
+	}
+	if v, ok := Choice(nil, []string{"x"}); !ok || v != "x" {
+		t.Errorf("Choice = %q, %v", v, ok)
+	}
+}
+
+func TestSample(t *testing.T) {
+	r := NewSeeded(5)
+	s := make([]int, 100)
+	for i := range s {
+		s[i] = i
+	}
+	got := Sample(r, s, 10)
+	if len(got) != 10 {
+		t.Fatalf("Sample(10) = %v", got)
+	}
+	seen := make(map[int]bool)
+	for _, v := range got {
+		if seen[v] {
+			t.Fatalf("Sample returned %d twice: %v", v, got)
+		}
+		seen[v] = true
+	}
+	for i, v := range s {
+		if v != i {
+			t.Fatal("Sample modified its input")
+		}
+	}
+	if got := Sample(r, s[:3], 5); len(got) != 3 {
+		t.Errorf("Sample(5) of 3 = %v", got)
+	}
+	if Sample(r, s, 0) != nil {
+		t.Error("Sample(0) should be nil")
+	}
+
+	// every element is as likely
+	counts := make([]int, 5)
+	for i := 0; i < 10000; i++ {
+		for _, v := range Sample(r, []int{0, 1, 2, 3, 4}, 2) {
+			counts[v]++
+		}
+	}
+	for v, c := range counts {
+		if c < 3600 || c > 4400 {
+			t.Errorf("element %d sampled %d times out of 10000, want about 4000", v, c)
+		}
+	}
+}
+
+func TestReservoir(t *testing.T) {
+	counts := make([]int, 10)
+	for round := 0; round < 5000; round++ {
+		res, err := NewReservoir[int](NewSeeded(int64(round)), 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 10; i++ {
+			res.Add(i)
+		}
+		if res.Seen() != 10 || len(res.Items()) != 3 {
+			t.Fatalf("Seen = %d, Items = %v", res.Seen(), res.Items())
+		}
+		for _, v := range res.Items() {
+			counts[v]++
+		}
+	}
+	for v, c := range counts {
+		if c < 1300 || c > 1700 {
+			t.Errorf("item %d kept %d times out of 5000, want about 1500", v, c)
+		}
+	}
+
+	res, err := NewReservoir[string](nil, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Add("a")
+	if items := res.Items(); len(items) != 1 || items[0] != "a" {
+		t.Errorf("short stream Items = %v", items)
+	}
+	for _, k := range []int{0, -1} {
+		if _, err := NewReservoir[string](nil, k); !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("NewReservoir(%d) error = %v", k, err)
+		}
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	w, err := NewWeighted([]string{"a", "b", "c", "d"}, []float64{1, 2, 7, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewSeeded(11)
+	counts := make(map[string]int)
+	const n = 100000
+	for i := 0; i < n; i++ {
+		counts[w.Pick(r)]++
+	}
+	for item, want := range map[string]float64{"a": 0.1, "b": 0.2, "c": 0.7, "d": 0} {
+		if got := float64(counts[item]) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("%s picked %.3f of the time, want %.1f", item, got, want)
+		}
+	}
+	if w.Len() != 4 {
+		t.Errorf("Len = %d", w.Len())
+	}
+
+	bad := [][]float64{nil, {1}, {1, -1}, {0, 0}, {1, math.NaN()}, {1, math.Inf(1)}}
+	for _, weights := range bad {
+		if _, err := NewWeighted([]int{1, 2}, weights); !errors.Is(err, ErrInvalidWeights) {
+			t.Errorf("NewWeighted(%v) error = %v", weights, err)
+		}
+	}
+}
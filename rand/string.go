@@ -0,0 +1,175 @@
+package rand
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Alphabets for String.
+const (
+	Digits       = "0123456789"
+	LowerLetters = "abcdefghijklmnopqrstuvwxyz"
+	UpperLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Letters      = LowerLetters + UpperLetters
+	Alphanumeric = Digits + Letters
+	// Symbols are the punctuation accepted by most password rules.
+	Symbols = "!@#$%^&*()-_=+[]{}<>?"
+	// Ambiguous characters are easily confused when read or typed.
+	Ambiguous = "0O1lI"
+)
+
+// ErrInvalidPolicy is returned by Password for a policy no password satisfies.
+var ErrInvalidPolicy = errors.New("invalid password policy")
+
+// Read fills p with random bytes, it never fails. Rand is an io.Reader.
+func (r *Rand) Read(p []byte) (int, error) {
+	for i := 0; i < len(p); i += 8 {
+		v := r.Uint64()
+		for j := i; j < i+8 && j < len(p); j++ {
+			p[j] = byte(v)
+			v >>= 8
+		}
+	}
+	return len(p), nil
+}
+
+// String returns n characters drawn uniformly from alphabet, which may
+// hold any runes. It panics if alphabet is empty.
+func (r *Rand) String(n int, alphabet string) string {
+	if alphabet == "" {
+		panic("rand: String with an empty alphabet")
+	}
+	if isASCII(alphabet) {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = alphabet[r.Intn(len(alphabet))]
+		}
+		return string(b)
+	}
+	runes := []rune(alphabet)
+	out := make([]rune, n)
+	for i := range out {
+		out[i] = runes[r.Intn(len(runes))]
+	}
+	return string(out)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// Code returns a numeric code of n digits, leading zeros included, such
+// as an SMS verification code.
+func (r *Rand) Code(n int) string {
+	return r.String(n, Digits)
+}
+
+// Token returns n random bytes encoded in unpadded URL-safe base64, for
+// API keys, session ids and links. 32 bytes give 256 bits, use a crypto
+// generator for them.
+func (r *Rand) Token(n int) string {
+	b := make([]byte, n)
+	_, _ = r.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// PasswordPolicy describes the passwords Password generates. Lower,
+// Upper, Digits and Symbols are the minimum count of each character class,
+// 0 allows the class without requiring it and a negative value forbids it.
+type PasswordPolicy struct {
+	Length  int
+	Lower   int
+	Upper   int
+	Digits  int
+	Symbols int
+	// SymbolSet replaces the Symbols alphabet when not empty.
+	SymbolSet string
+	// NoAmbiguous leaves out the characters of Ambiguous.
+	NoAmbiguous bool
+}
+
+// DefaultPasswordPolicy asks for 16 characters with at least one of each class.
+var DefaultPasswordPolicy = PasswordPolicy{Length: 16, Lower: 1, Upper: 1, Digits: 1, Symbols: 1}
+
+// Password returns a password satisfying policy. It returns
+// ErrInvalidPolicy if the minimums exceed the length or no class is allowed.
+func (r *Rand) Password(policy PasswordPolicy) (string, error) {
+	symbols := Symbols
+	if policy.SymbolSet != "" {
+		symbols = policy.SymbolSet
+	}
+	classes := []struct {
+		alphabet string
+		min      int
+	}{
+		{LowerLetters, policy.Lower},
+		{UpperLetters, policy.Upper},
+		{Digits, policy.Digits},
+		{symbols, policy.Symbols},
+	}
+
+	var all strings.Builder
+	var out []rune
+	required := 0
+	for _, c := range classes {
+		if c.min < 0 {
+			continue
+		}
+		alphabet := c.alphabet
+		if policy.NoAmbiguous {
+			alphabet = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(Ambiguous, r) {
+					return -1
+				}
+				return r
+			}, alphabet)
+		}
+		if alphabet == "" {
+			if c.min > 0 {
+				return "", fmt.Errorf("%w: empty character class", ErrInvalidPolicy)
+			}
+			continue
+		}
+		all.WriteString(alphabet)
+		out = append(out, []rune(r.String(c.min, alphabet))...)
+		required += c.min
+	}
+	if policy.Length <= 0 || required > policy.Length || all.Len() == 0 {
+		return "", fmt.Errorf("%w: length %d, %d required characters", ErrInvalidPolicy, policy.Length, required)
+	}
+
+	out = append(out, []rune(r.String(policy.Length-required, all.String()))...)
+	Shuffle(r, out)
+	return string(out), nil
+}
+
+// RandString returns n characters of alphabet from the crypto-secure
+// default generator.
+func RandString(n int, alphabet string) string {
+	return defaultRand.String(n, alphabet)
+}
+
+// RandCode returns a numeric code of n digits from the crypto-secure
+// default generator.
+func RandCode(n int) string {
+	return defaultRand.Code(n)
+}
+
+// RandToken returns n bytes of URL-safe base64 from the crypto-secure
+// default generator.
+func RandToken(n int) string {
+	return defaultRand.Token(n)
+}
+
+// RandPassword returns a password satisfying policy from the
+// crypto-secure default generator.
+func RandPassword(policy PasswordPolicy) (string, error) {
+	return defaultRand.Password(policy)
+}
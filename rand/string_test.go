@@ -0,0 +1,76 @@
+package rand
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestString(t *testing.T) {
+	r := NewSeeded(3)
+	s := r.String(64, "ab")
+	if len(s) != 64 || strings.Trim(s, "ab") != "" {
+		t.Errorf("String(64, ab) = %q", s)
+	}
+	s = r.String(10, "红黄蓝")
+	if utf8.RuneCountInString(s) != 10 || strings.Trim(s, "红黄蓝") != "" {
+		t.Errorf("String(10, 红黄蓝) = %q", s)
+	}
+	if NewSeeded(9).String(20, Alphanumeric) != NewSeeded(9).String(20, Alphanumeric) {
+		t.Error("seeded String is not deterministic")
+	}
+
+	code := RandCode(6)
+	if len(code) != 6 || strings.Trim(code, Digits) != "" {
+		t.Errorf("RandCode(6) = %q", code)
+	}
+
+	tok := RandToken(32)
+	if b, err := base64.RawURLEncoding.DecodeString(tok); err != nil || len(b) != 32 {
+		t.Errorf("RandToken(32) = %q, %v", tok, err)
+	}
+	if RandToken(16) == RandToken(16) {
+		t.Error("two tokens are equal")
+	}
+}
+
+func TestPassword(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		p, err := RandPassword(DefaultPasswordPolicy)
+		if err != nil || len(p) != 16 {
+			t.Fatalf("RandPassword = %q, %v", p, err)
+		}
+		for _, class := range []string{LowerLetters, UpperLetters, Digits, Symbols} {
+			if !strings.ContainsAny(p, class) {
+				t.Fatalf("password %q misses a character of %q", p, class)
+			}
+		}
+	}
+
+	p, err := NewSeeded(1).Password(PasswordPolicy{Length: 12, Digits: 4, Symbols: -1, NoAmbiguous: true})
+	if err != nil || len(p) != 12 || strings.ContainsAny(p, Symbols+Ambiguous) {
+		t.Errorf("Password without symbols = %q, %v", p, err)
+	}
+	digits := 0
+	for _, c := range p {
+		if strings.ContainsRune(Digits, c) {
+			digits++
+		}
+	}
+	if digits < 4 {
+		t.Errorf("Password %q has %d digits, want at least 4", p, digits)
+	}
+
+	bad := []PasswordPolicy{
+		{Length: 3, Lower: 2, Upper: 2},
+		{Length: 8, Lower: -1, Upper: -1, Digits: -1, Symbols: -1},
+		{Length: 0},
+	}
+	for _, policy := range bad {
+		if _, err := RandPassword(policy); !errors.Is(err, ErrInvalidPolicy) {
+			t.Errorf("RandPassword(%+v) error = %v", policy, err)
+		}
+	}
+}